@@ -1,36 +1,923 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/edenreich/n8n-cli/n8n"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Client -
 type client struct {
-	N8NClient *n8n.Client
+	// BaseURL is the URL of the n8n instance. HTTPClient carries the TLS and
+	// proxy settings of the provider.
+	BaseURL    string
+	APIKey     string
+	HTTPClient *http.Client
+
+	// MaxRetries is the number of times a rate limited or failed request is
+	// retried, waiting at most RetryMaxWait between two attempts.
+	MaxRetries   int
+	RetryMaxWait time.Duration
+
+	// limiter limits the rate and concurrency of requests to the n8n instance.
+	limiter *rateLimiter
+
+	// credentialSchemas caches credential type schemas, which only change
+	// when n8n itself is upgraded.
+	credentialSchemasMu sync.Mutex
+	credentialSchemas   map[string]*credentialSchema
 }
 
-func (c *client) getWorkflow(ctx context.Context, workflowID string) (*workflowDataSourceModel, error) {
-	workflow, err := c.N8NClient.GetWorkflow(workflowID)
+// apiError is returned when the n8n API answers with a non-2xx status.
+type apiError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("API returned error %d: %s", e.StatusCode, e.Body)
+}
+
+// Message returns the message n8n included in the error response, falling
+// back to the raw body.
+func (e *apiError) Message() string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(e.Body), &body); err != nil || body.Message == "" {
+		return e.Body
+	}
+
+	return body.Message
+}
+
+// doRequest sends a request to the n8n public API and decodes the JSON
+// response into out, if given.
+func (c *client) doRequest(ctx context.Context, method, path string, body, out interface{}) error {
+	var encoded []byte
+	if body != nil {
+		var err error
+		encoded, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error marshaling request: %w", err)
+		}
+	}
+
+	tflog.Debug(ctx, "Sending n8n API request", map[string]interface{}{"method": method, "path": path})
+
+	statusCode, respBody, err := c.send(ctx, isRetryable, func() (*http.Request, error) {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(encoded)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+"/api/v1"+path, reqBody)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-N8N-API-KEY", c.APIKey)
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if statusCode < 200 || statusCode > 299 {
+		return &apiError{StatusCode: statusCode, Body: string(respBody)}
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// listPage is the envelope of the cursor-paginated n8n list endpoints.
+type listPage[T any] struct {
+	Data       []T     `json:"data"`
+	NextCursor *string `json:"nextCursor"`
+}
+
+// listAll follows the cursor of a paginated list endpoint and returns all items.
+func listAll[T any](ctx context.Context, c *client, path string, query url.Values) ([]T, error) {
+	return listAtMost[T](ctx, c, path, query, 0)
+}
+
+// listAtMost follows the cursor of a paginated list endpoint until it has
+// max items. A max of 0 returns all items.
+func listAtMost[T any](ctx context.Context, c *client, path string, query url.Values, max int) ([]T, error) {
+	query = cloneValues(query)
+	if query.Get("limit") == "" {
+		query.Set("limit", "250")
+	}
+	if max > 0 && max < 250 {
+		query.Set("limit", strconv.Itoa(max))
+	}
+
+	items := []T{}
+	for {
+		var page listPage[T]
+		if err := c.doRequest(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Data...)
+
+		if max > 0 && len(items) >= max {
+			return items[:max], nil
+		}
+		if page.NextCursor == nil || *page.NextCursor == "" {
+			return items, nil
+		}
+		query.Set("cursor", *page.NextCursor)
+	}
+}
+
+// cloneValues returns a copy of query that is safe to modify.
+func cloneValues(query url.Values) url.Values {
+	clone := url.Values{}
+	for k, v := range query {
+		clone[k] = append([]string(nil), v...)
+	}
+
+	return clone
+}
+
+func (c *client) listWorkflows(ctx context.Context, params n8n.GetWorkflowsParams) ([]n8n.Workflow, error) {
+	query := url.Values{}
+	if params.Active != nil {
+		query.Set("active", strconv.FormatBool(*params.Active))
+	}
+	if params.Tags != nil {
+		query.Set("tags", *params.Tags)
+	}
+	if params.Name != nil {
+		query.Set("name", *params.Name)
+	}
+	if params.ProjectId != nil {
+		query.Set("projectId", *params.ProjectId)
+	}
+	query.Set("excludePinnedData", "true")
+
+	workflows, err := listAll[n8n.Workflow](ctx, c, "/workflows", query)
 	if err != nil {
+		return nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	return workflows, nil
+}
+
+func (c *client) getWorkflow(ctx context.Context, workflowID string) (*workflowDataSourceModel, error) {
+	var workflow json.RawMessage
+	if err := c.doRequest(ctx, http.MethodGet, "/workflows/"+url.PathEscape(workflowID), nil, &workflow); err != nil {
 		return nil, fmt.Errorf("failed to get workflow: %w", err)
 	}
 
+	return flattenWorkflowResponse(workflow)
+}
+
+// createWorkflow creates the workflow and sets its tags. When setting the tags
+// fails, the created workflow is returned together with the error, so it can
+// be kept in state.
+func (c *client) createWorkflow(ctx context.Context, plan *workflowDataSourceModel) (*workflowDataSourceModel, error) {
+	body, err := workflowRequest(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	var created json.RawMessage
+	if err := c.doRequest(ctx, http.MethodPost, "/workflows", body, &created); err != nil {
+		return nil, fmt.Errorf("failed to create workflow: %w", err)
+	}
+
+	wfModel, err := flattenWorkflowResponse(created)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tags are not accepted on the workflow body and must be set separately
+	if plan.Tags != nil {
+		if err := c.setWorkflowTags(ctx, wfModel, plan.Tags); err != nil {
+			return wfModel, err
+		}
+	}
+
+	return wfModel, nil
+}
+
+// updateWorkflow updates the workflow and sets its tags. When setting the tags
+// fails, the updated workflow is returned together with the error, so the
+// state can follow the new content.
+func (c *client) updateWorkflow(ctx context.Context, workflowID string, plan *workflowDataSourceModel) (*workflowDataSourceModel, error) {
+	body, err := workflowRequest(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated json.RawMessage
+	if err := c.doRequest(ctx, http.MethodPut, "/workflows/"+url.PathEscape(workflowID), body, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update workflow: %w", err)
+	}
+
+	wfModel, err := flattenWorkflowResponse(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.Tags != nil {
+		if err := c.setWorkflowTags(ctx, wfModel, plan.Tags); err != nil {
+			return wfModel, err
+		}
+	}
+
+	return wfModel, nil
+}
+
+// workflowRequest returns the body of a workflow create or update request.
+// A workflow export is sent as configured, apart from its server-managed
+// fields, so fields this provider does not model are kept.
+func workflowRequest(plan *workflowDataSourceModel) (interface{}, error) {
+	if !plan.WorkflowJSON.IsNull() && !plan.WorkflowJSON.IsUnknown() {
+		return decodeWorkflowJSON(plan.WorkflowJSON.ValueString())
+	}
+
+	workflow, err := expandWorkflow(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflowRequestBody(workflow), nil
+}
+
+// workflowRequestBody strips the read-only fields the API rejects on
+// workflow create and update requests.
+func workflowRequestBody(workflow *n8n.Workflow) *n8n.Workflow {
+	body := *workflow
+	body.Id = nil
+	body.Active = nil
+	body.CreatedAt = nil
+	body.UpdatedAt = nil
+	body.Tags = nil
+
+	return &body
+}
+
+// transferWorkflow moves a workflow to another project.
+func (c *client) transferWorkflow(ctx context.Context, workflowID, projectID string) error {
+	body := map[string]string{"destinationProjectId": projectID}
+	if err := c.doRequest(ctx, http.MethodPut, "/workflows/"+url.PathEscape(workflowID)+"/transfer", body, nil); err != nil {
+		return fmt.Errorf("failed to transfer workflow: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) deleteWorkflow(ctx context.Context, workflowID string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, "/workflows/"+url.PathEscape(workflowID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete workflow: %w", err)
+	}
+
+	return nil
+}
+
+// setWorkflowTags replaces the tags of the workflow and records the result on wfModel.
+func (c *client) setWorkflowTags(ctx context.Context, wfModel *workflowDataSourceModel, planTags []tag) error {
+	tagIDs := make([]string, len(planTags))
+	for i, t := range planTags {
+		tagIDs[i] = t.ID.ValueString()
+	}
+
+	workflowTags, err := c.updateWorkflowTags(ctx, wfModel.ID.ValueString(), tagIDs)
+	if err != nil {
+		return err
+	}
+
+	wfModel.Tags = flattenTags(workflowTags)
+
+	return nil
+}
+
+func (c *client) getWorkflowTags(ctx context.Context, workflowID string) ([]n8n.Tag, error) {
+	var workflowTags []n8n.Tag
+	if err := c.doRequest(ctx, http.MethodGet, "/workflows/"+url.PathEscape(workflowID)+"/tags", nil, &workflowTags); err != nil {
+		return nil, fmt.Errorf("failed to get workflow tags: %w", err)
+	}
+
+	return workflowTags, nil
+}
+
+func (c *client) updateWorkflowTags(ctx context.Context, workflowID string, tagIDs []string) ([]n8n.Tag, error) {
+	body := make(n8n.TagIds, len(tagIDs))
+	for i, id := range tagIDs {
+		body[i].Id = id
+	}
+
+	var workflowTags []n8n.Tag
+	if err := c.doRequest(ctx, http.MethodPut, "/workflows/"+url.PathEscape(workflowID)+"/tags", body, &workflowTags); err != nil {
+		return nil, fmt.Errorf("failed to update workflow tags: %w", err)
+	}
+
+	return workflowTags, nil
+}
+
+func (c *client) activateWorkflow(ctx context.Context, workflowID string) (*n8n.Workflow, error) {
+	var workflow n8n.Workflow
+	if err := c.doRequest(ctx, http.MethodPost, "/workflows/"+url.PathEscape(workflowID)+"/activate", nil, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to activate workflow: %w", err)
+	}
+
+	return &workflow, nil
+}
+
+func (c *client) deactivateWorkflow(ctx context.Context, workflowID string) (*n8n.Workflow, error) {
+	var workflow n8n.Workflow
+	if err := c.doRequest(ctx, http.MethodPost, "/workflows/"+url.PathEscape(workflowID)+"/deactivate", nil, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to deactivate workflow: %w", err)
+	}
+
+	return &workflow, nil
+}
+
+func (c *client) createCredential(ctx context.Context, credential *n8n.Credential) (*n8n.CreateCredentialResponse, error) {
+	var created n8n.CreateCredentialResponse
+	if err := c.doRequest(ctx, http.MethodPost, "/credentials", credential, &created); err != nil {
+		return nil, fmt.Errorf("failed to create credential: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (c *client) deleteCredential(ctx context.Context, credentialID string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, "/credentials/"+url.PathEscape(credentialID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete credential: %w", err)
+	}
+
+	return nil
+}
+
+// transferCredential moves a credential to another project.
+func (c *client) transferCredential(ctx context.Context, credentialID, projectID string) error {
+	body := map[string]string{"destinationProjectId": projectID}
+	if err := c.doRequest(ctx, http.MethodPut, "/credentials/"+url.PathEscape(credentialID)+"/transfer", body, nil); err != nil {
+		return fmt.Errorf("failed to transfer credential: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) getTag(ctx context.Context, tagID string) (*n8n.Tag, error) {
+	var t n8n.Tag
+	if err := c.doRequest(ctx, http.MethodGet, "/tags/"+url.PathEscape(tagID), nil, &t); err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (c *client) listTags(ctx context.Context) ([]n8n.Tag, error) {
+	tags, err := listAll[n8n.Tag](ctx, c, "/tags", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (c *client) createTag(ctx context.Context, name string) (*n8n.Tag, error) {
+	var t n8n.Tag
+	if err := c.doRequest(ctx, http.MethodPost, "/tags", n8n.Tag{Name: name}, &t); err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (c *client) updateTag(ctx context.Context, tagID, name string) (*n8n.Tag, error) {
+	var t n8n.Tag
+	if err := c.doRequest(ctx, http.MethodPut, "/tags/"+url.PathEscape(tagID), n8n.Tag{Name: name}, &t); err != nil {
+		return nil, fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (c *client) deleteTag(ctx context.Context, tagID string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, "/tags/"+url.PathEscape(tagID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) listVariables(ctx context.Context) ([]n8n.Variable, error) {
+	variables, err := listAll[n8n.Variable](ctx, c, "/variables", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variables: %w", err)
+	}
+
+	return variables, nil
+}
+
+// findVariable returns the variable matching the predicate, or nil if there
+// is none. The API has no endpoint to get a single variable.
+func (c *client) findVariable(ctx context.Context, match func(n8n.Variable) bool) (*n8n.Variable, error) {
+	variables, err := c.listVariables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range variables {
+		if match(v) {
+			return &v, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// createVariable creates a variable. Older n8n versions answer without a body,
+// so the created variable is looked up by its key in that case.
+func (c *client) createVariable(ctx context.Context, key, value string) (*n8n.Variable, error) {
+	var v n8n.Variable
+	if err := c.doRequest(ctx, http.MethodPost, "/variables", n8n.Variable{Key: key, Value: value}, &v); err != nil {
+		return nil, fmt.Errorf("failed to create variable: %w", err)
+	}
+	if v.Id != nil {
+		return &v, nil
+	}
+
+	created, err := c.findVariable(ctx, func(v n8n.Variable) bool { return v.Key == key })
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, fmt.Errorf("failed to create variable: variable %q not found after creation", key)
+	}
+
+	return created, nil
+}
+
+func (c *client) updateVariable(ctx context.Context, variableID, key, value string) error {
+	if err := c.doRequest(ctx, http.MethodPut, "/variables/"+url.PathEscape(variableID), n8n.Variable{Key: key, Value: value}, nil); err != nil {
+		return fmt.Errorf("failed to update variable: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) deleteVariable(ctx context.Context, variableID string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, "/variables/"+url.PathEscape(variableID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete variable: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) listProjects(ctx context.Context) ([]n8n.Project, error) {
+	projects, err := listAll[n8n.Project](ctx, c, "/projects", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// getProject returns the project with the given ID, or nil if there is none.
+// The API has no endpoint to get a single project.
+func (c *client) getProject(ctx context.Context, projectID string) (*n8n.Project, error) {
+	projects, err := c.listProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range projects {
+		if p.Id != nil && *p.Id == projectID {
+			return &p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *client) createProject(ctx context.Context, name string) (*n8n.Project, error) {
+	var p n8n.Project
+	if err := c.doRequest(ctx, http.MethodPost, "/projects", n8n.Project{Name: name}, &p); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return &p, nil
+}
+
+func (c *client) updateProject(ctx context.Context, projectID, name string) error {
+	if err := c.doRequest(ctx, http.MethodPut, "/projects/"+url.PathEscape(projectID), n8n.Project{Name: name}, nil); err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) deleteProject(ctx context.Context, projectID string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, "/projects/"+url.PathEscape(projectID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	return nil
+}
+
+// projectRelation assigns a project role to a user.
+type projectRelation struct {
+	UserID string `json:"userId"`
+	Role   string `json:"role"`
+}
+
+func (c *client) addProjectUser(ctx context.Context, projectID, userID, role string) error {
+	body := map[string][]projectRelation{"relations": {{UserID: userID, Role: role}}}
+	if err := c.doRequest(ctx, http.MethodPost, "/projects/"+url.PathEscape(projectID)+"/users", body, nil); err != nil {
+		return fmt.Errorf("failed to add user to project: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) changeProjectUserRole(ctx context.Context, projectID, userID, role string) error {
+	body := map[string]string{"role": role}
+	if err := c.doRequest(ctx, http.MethodPatch, "/projects/"+url.PathEscape(projectID)+"/users/"+url.PathEscape(userID), body, nil); err != nil {
+		return fmt.Errorf("failed to change project role of user: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) removeProjectUser(ctx context.Context, projectID, userID string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, "/projects/"+url.PathEscape(projectID)+"/users/"+url.PathEscape(userID), nil, nil); err != nil {
+		return fmt.Errorf("failed to remove user from project: %w", err)
+	}
+
+	return nil
+}
+
+// userInvitation is the result of inviting a single user.
+type userInvitation struct {
+	User struct {
+		ID              string `json:"id"`
+		Email           string `json:"email"`
+		InviteAcceptURL string `json:"inviteAcceptUrl"`
+		EmailSent       bool   `json:"emailSent"`
+	} `json:"user"`
+	Error string `json:"error"`
+}
+
+func (c *client) listUsers(ctx context.Context) ([]n8n.User, error) {
+	users, err := listAll[n8n.User](ctx, c, "/users", url.Values{"includeRole": {"true"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (c *client) getUser(ctx context.Context, userID string) (*n8n.User, error) {
+	var u n8n.User
+	if err := c.doRequest(ctx, http.MethodGet, "/users/"+url.PathEscape(userID)+"?includeRole=true", nil, &u); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &u, nil
+}
+
+// inviteUser creates a pending user and sends an invitation if n8n has email set up.
+func (c *client) inviteUser(ctx context.Context, email, role string) (*userInvitation, error) {
+	body := []map[string]string{{"email": email, "role": role}}
+	var invitations []userInvitation
+	if err := c.doRequest(ctx, http.MethodPost, "/users", body, &invitations); err != nil {
+		return nil, fmt.Errorf("failed to invite user: %w", err)
+	}
+	if len(invitations) != 1 {
+		return nil, fmt.Errorf("failed to invite user: expected 1 invitation, got %d", len(invitations))
+	}
+	if invitations[0].Error != "" {
+		return nil, fmt.Errorf("failed to invite user: %s", invitations[0].Error)
+	}
+
+	return &invitations[0], nil
+}
+
+func (c *client) changeUserRole(ctx context.Context, userID, role string) error {
+	body := map[string]string{"newRoleName": role}
+	if err := c.doRequest(ctx, http.MethodPatch, "/users/"+url.PathEscape(userID)+"/role", body, nil); err != nil {
+		return fmt.Errorf("failed to change role of user: %w", err)
+	}
+
+	return nil
+}
+
+// deleteUser deletes a user. If transferTo is set, the workflows and
+// credentials of the user are transferred to that user instead of deleted.
+func (c *client) deleteUser(ctx context.Context, userID, transferTo string) error {
+	path := "/users/" + url.PathEscape(userID)
+	if transferTo != "" {
+		path += "?" + url.Values{"transferId": {transferTo}}.Encode()
+	}
+	if err := c.doRequest(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return nil
+}
+
+// flexibleID is an ID that n8n encodes either as a JSON string or a number,
+// depending on the version.
+type flexibleID string
+
+// UnmarshalJSON accepts a JSON string, number or null.
+func (id *flexibleID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = flexibleID(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid ID %s: %w", data, err)
+	}
+	*id = flexibleID(n.String())
+
+	return nil
+}
+
+// apiExecution is an execution as returned by the API. n8n.Execution lacks
+// the status and expects numeric workflow IDs, so it is not used.
+type apiExecution struct {
+	ID         flexibleID      `json:"id"`
+	WorkflowID flexibleID      `json:"workflowId"`
+	Status     string          `json:"status"`
+	Mode       string          `json:"mode"`
+	Finished   bool            `json:"finished"`
+	RetryOf    flexibleID      `json:"retryOf"`
+	StartedAt  *time.Time      `json:"startedAt"`
+	StoppedAt  *time.Time      `json:"stoppedAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// executionFilter narrows down the executions to list.
+type executionFilter struct {
+	WorkflowID string
+	Status     string
+	ProjectID  string
+
+	// Limit is the maximum number of executions to return, 0 for all.
+	Limit int
+}
+
+// listExecutions returns the executions matching the filter, most recent first.
+func (c *client) listExecutions(ctx context.Context, filter executionFilter) ([]apiExecution, error) {
+	query := url.Values{}
+	if filter.WorkflowID != "" {
+		query.Set("workflowId", filter.WorkflowID)
+	}
+	if filter.Status != "" {
+		query.Set("status", filter.Status)
+	}
+	if filter.ProjectID != "" {
+		query.Set("projectId", filter.ProjectID)
+	}
+	query.Set("includeData", "false")
+
+	executions, err := listAtMost[apiExecution](ctx, c, "/executions", query, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+
+	return executions, nil
+}
+
+func (c *client) getExecution(ctx context.Context, executionID string, includeData bool) (*apiExecution, error) {
+	query := url.Values{"includeData": {strconv.FormatBool(includeData)}}
+	var e apiExecution
+	if err := c.doRequest(ctx, http.MethodGet, "/executions/"+url.PathEscape(executionID)+"?"+query.Encode(), nil, &e); err != nil {
+		return nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+
+	return &e, nil
+}
+
+// triggerWebhook calls the production webhook of an active workflow with the
+// given extra headers. Webhooks are served outside the API and do not take the
+// API key. The call is only retried when rate limited, see isRateLimited.
+func (c *client) triggerWebhook(ctx context.Context, method, webhookPath string, header http.Header, body []byte) error {
+	tflog.Debug(ctx, "Calling n8n webhook", map[string]interface{}{"method": method, "path": webhookPath})
+
+	statusCode, respBody, err := c.send(ctx, isRateLimited, func() (*http.Request, error) {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+"/webhook/"+strings.TrimPrefix(webhookPath, "/"), reqBody)
+		if err != nil {
+			return nil, err
+		}
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		return req, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+
+	if statusCode < 200 || statusCode > 299 {
+		return fmt.Errorf("failed to call webhook: %w", &apiError{StatusCode: statusCode, Body: string(respBody)})
+	}
+
+	return nil
+}
+
+// auditReport is a risk report of the security audit.
+type auditReport struct {
+	Risk     string         `json:"risk"`
+	Sections []auditSection `json:"sections"`
+}
+
+// auditSection is a group of findings of the same kind.
+type auditSection struct {
+	Title          string          `json:"title"`
+	Description    string          `json:"description"`
+	Recommendation string          `json:"recommendation"`
+	Location       []auditLocation `json:"location"`
+}
+
+// auditLocation is a single finding, pointing at a credential, node or workflow.
+type auditLocation struct {
+	Kind         string     `json:"kind"`
+	ID           flexibleID `json:"id"`
+	Name         string     `json:"name"`
+	WorkflowID   flexibleID `json:"workflowId"`
+	WorkflowName string     `json:"workflowName"`
+	NodeID       flexibleID `json:"nodeId"`
+	NodeName     string     `json:"nodeName"`
+	NodeType     string     `json:"nodeType"`
+}
+
+// runAudit runs the security audit and returns the reports sorted by risk.
+// Empty categories audit everything; a daysAbandonedWorkflow of 0 uses the
+// n8n default.
+func (c *client) runAudit(ctx context.Context, categories []string, daysAbandonedWorkflow int) ([]auditReport, error) {
+	options := map[string]interface{}{}
+	if len(categories) > 0 {
+		options["categories"] = categories
+	}
+	if daysAbandonedWorkflow > 0 {
+		options["daysAbandonedWorkflow"] = daysAbandonedWorkflow
+	}
+
+	var raw json.RawMessage
+	if err := c.doRequest(ctx, http.MethodPost, "/audit", map[string]interface{}{"additionalOptions": options}, &raw); err != nil {
+		return nil, fmt.Errorf("failed to run audit: %w", err)
+	}
+
+	// n8n answers with an empty array instead of an object if there are no findings
+	reports := []auditReport{}
+	if len(bytes.TrimSpace(raw)) == 0 || bytes.TrimSpace(raw)[0] != '{' {
+		return reports, nil
+	}
+
+	var byName map[string]auditReport
+	if err := json.Unmarshal(raw, &byName); err != nil {
+		return nil, fmt.Errorf("error decoding audit: %w", err)
+	}
+	for _, report := range byName {
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Risk < reports[j].Risk })
+
+	return reports, nil
+}
+
+// pullSourceControl pulls the workflows, credentials, tags and variables of
+// the connected Git branch into the instance.
+func (c *client) pullSourceControl(ctx context.Context, pull n8n.Pull) (*n8n.ImportResult, error) {
+	var result n8n.ImportResult
+	if err := c.doRequest(ctx, http.MethodPost, "/source-control/pull", pull, &result); err != nil {
+		return nil, fmt.Errorf("failed to pull from source control: %w", err)
+	}
+
+	return &result, nil
+}
+
+// getCredentialSchema returns the data schema of a credential type.
+func (c *client) getCredentialSchema(ctx context.Context, credentialType string) (*credentialSchema, error) {
+	c.credentialSchemasMu.Lock()
+	cached, ok := c.credentialSchemas[credentialType]
+	c.credentialSchemasMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	// The lock is not held during the request, so lookups of other types do
+	// not wait for it. Concurrent lookups of the same type may both fetch it.
+
+	var raw json.RawMessage
+	if err := c.doRequest(ctx, http.MethodGet, "/credentials/schema/"+url.PathEscape(credentialType), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get credential schema: %w", err)
+	}
+
+	var s credentialSchema
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("failed to decode credential schema: %w", err)
+	}
+	s.Raw = raw
+
+	c.credentialSchemasMu.Lock()
+	defer c.credentialSchemasMu.Unlock()
+	if c.credentialSchemas == nil {
+		c.credentialSchemas = map[string]*credentialSchema{}
+	}
+	c.credentialSchemas[credentialType] = &s
+
+	return &s, nil
+}
+
+// isNotFound reports whether err was caused by the n8n API answering 404.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// flattenWorkflowResponse maps a workflow returned by the n8n API to the
+// Terraform model, including workflow_json. The document is rendered from the
+// response itself rather than from n8n.Workflow, so it keeps the fields the
+// struct does not model.
+func flattenWorkflowResponse(raw json.RawMessage) (*workflowDataSourceModel, error) {
+	var workflow n8n.Workflow
+	if err := json.Unmarshal(raw, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow: %w", err)
+	}
+
+	wfModel, err := flattenWorkflow(&workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	workflowJSON, err := normalizeWorkflowJSON(string(raw))
+	if err != nil {
+		return nil, err
+	}
+	wfModel.WorkflowJSON = newWorkflowJSONValue(workflowJSON)
+
+	return wfModel, nil
+}
+
+// flattenWorkflow maps an n8n API workflow to the Terraform model.
+func flattenWorkflow(workflow *n8n.Workflow) (*workflowDataSourceModel, error) {
 	var wfModel workflowDataSourceModel
 
 	// Map top-level attributes
 	wfModel.ID = types.StringPointerValue(workflow.Id)
 	wfModel.Name = types.StringValue(workflow.Name)
 	wfModel.Active = types.BoolPointerValue(workflow.Active)
-	wfModel.CreatedAt = types.StringValue(workflow.CreatedAt.String())
-	wfModel.UpdatedAt = types.StringValue(workflow.UpdatedAt.String())
+	wfModel.CreatedAt = convertTimeToTypesString(workflow.CreatedAt)
+	wfModel.UpdatedAt = convertTimeToTypesString(workflow.UpdatedAt)
 
 	// Map Nodes
 	nodes := make([]node, len(workflow.Nodes))
 	for i, n := range workflow.Nodes {
+		parameters, err := convertMapToJSON(n.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert parameters of node %d: %w", i, err)
+		}
+		credentials, err := convertMapToJSON(n.Credentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert credentials of node %d: %w", i, err)
+		}
+
 		nodes[i] = node{
 			ID:               types.StringPointerValue(n.Id),
 			Name:             types.StringPointerValue(n.Name),
@@ -39,53 +926,540 @@ func (c *client) getWorkflow(ctx context.Context, workflowID string) (*workflowD
 			NotesInFlow:      types.BoolPointerValue(n.NotesInFlow),
 			Notes:            types.StringPointerValue(n.Notes),
 			Type:             types.StringPointerValue(n.Type),
-			TypeVersion:      types.Float32PointerValue(n.TypeVersion),
+			TypeVersion:      convertFloat32ToTypesFloat64(n.TypeVersion),
 			ExecuteOnce:      types.BoolPointerValue(n.ExecuteOnce),
 			AlwaysOutputData: types.BoolPointerValue(n.AlwaysOutputData),
 			RetryOnFail:      types.BoolPointerValue(n.RetryOnFail),
-			MaxTries:         types.Float32PointerValue(n.MaxTries),
-			WaitBetweenTries: types.Float32PointerValue(n.WaitBetweenTries),
+			MaxTries:         convertFloat32ToTypesInt64(n.MaxTries),
+			WaitBetweenTries: convertFloat32ToTypesInt64(n.WaitBetweenTries),
 			ContinueOnFail:   types.BoolPointerValue(n.ContinueOnFail),
 			OnError:          types.StringPointerValue(n.OnError),
 			Position:         convertInt64SliceToTypesInt64Slice(n.Position),
-			Parameters:       convertMapToTypesMap(ctx, n.Parameters),
-			Credentials:      convertMapToTypesMap(ctx, n.Credentials),
-			CreatedAt:        types.StringValue(n.CreatedAt.String()),
-			UpdatedAt:        types.StringValue(n.UpdatedAt.String()),
+			Parameters:       parameters,
+			Credentials:      credentials,
+			CreatedAt:        convertTimeToTypesString(n.CreatedAt),
+			UpdatedAt:        convertTimeToTypesString(n.UpdatedAt),
 		}
 	}
 	wfModel.Nodes = nodes
 
 	// Map Connections
-	connections, diags := types.MapValueFrom(ctx, types.StringType, workflow.Connections)
-	if diags.HasError() {
-		return nil, fmt.Errorf("failed to convert connections: %v", diags)
+	connections, err := flattenConnections(workflow.Connections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert connections: %w", err)
 	}
 	wfModel.Connections = connections
 
 	// Map Settings
-	wfModel.Settings = settings{
+	wfModel.Settings = &settings{
 		SaveExecutionProgress:    types.BoolPointerValue(workflow.Settings.SaveExecutionProgress),
 		SaveManualExecutions:     types.BoolPointerValue(workflow.Settings.SaveManualExecutions),
-		SaveDataErrorExecution:   types.StringPointerValue(workflow.Settings.SaveDataErrorExecution),
-		SaveDataSuccessExecution: types.StringPointerValue(workflow.Settings.SaveDataSuccessExecution),
-		ExecutionTimeout:         types.Int64PointerValue(workflow.Settings.ExecutionTimeout),
+		SaveDataErrorExecution:   types.StringPointerValue((*string)(workflow.Settings.SaveDataErrorExecution)),
+		SaveDataSuccessExecution: types.StringPointerValue((*string)(workflow.Settings.SaveDataSuccessExecution)),
+		ExecutionTimeout:         convertFloat32ToTypesInt64(workflow.Settings.ExecutionTimeout),
 		ErrorWorkflow:            types.StringPointerValue(workflow.Settings.ErrorWorkflow),
 		Timezone:                 types.StringPointerValue(workflow.Settings.Timezone),
 		ExecutionOrder:           types.StringPointerValue(workflow.Settings.ExecutionOrder),
 	}
 
-	// Map Tags
-	tags := make([]tag, len(workflow.Tags))
-	for i, t := range workflow.Tags {
-		tags[i] = tag{
-			ID:        types.StringValue(t.ID),
-			Name:      types.StringValue(t.Name),
-			CreatedAt: types.StringValue(t.CreatedAt.String()),
-			UpdatedAt: types.StringValue(t.UpdatedAt.String()),
+	// Map Static Data
+	wfModel.StaticData = types.StringNull()
+	if workflow.StaticData != nil {
+		staticData, err := workflow.StaticData.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert static data: %w", err)
+		}
+		if len(staticData) > 0 && string(staticData) != "null" {
+			var compacted bytes.Buffer
+			if err := json.Compact(&compacted, staticData); err != nil {
+				return nil, fmt.Errorf("failed to convert static data: %w", err)
+			}
+			wfModel.StaticData = types.StringValue(compacted.String())
 		}
 	}
-	wfModel.Tags = tags
+
+	// Map Tags
+	if workflow.Tags != nil {
+		wfModel.Tags = flattenTags(*workflow.Tags)
+	} else {
+		wfModel.Tags = []tag{}
+	}
 
 	return &wfModel, nil
 }
+
+// apiConnection is a single edge of the n8n connections document, which has
+// the shape {sourceNode: {outputType: [[{node, type, index}]]}}.
+type apiConnection struct {
+	Node  string `json:"node"`
+	Type  string `json:"type"`
+	Index int64  `json:"index"`
+}
+
+// flattenConnections maps the n8n connections document to one entry per edge.
+// Entries are sorted so the result is stable across reads.
+func flattenConnections(apiConnections map[string]interface{}) ([]connection, error) {
+	encoded, err := json.Marshal(apiConnections)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded map[string]map[string][][]apiConnection
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, err
+	}
+
+	connections := []connection{}
+	for sourceNode, outputs := range decoded {
+		for outputType, outputIndexes := range outputs {
+			for outputIndex, targets := range outputIndexes {
+				for _, target := range targets {
+					connections = append(connections, connection{
+						SourceNode:  types.StringValue(sourceNode),
+						OutputType:  types.StringValue(outputType),
+						OutputIndex: types.Int64Value(int64(outputIndex)),
+						TargetNode:  types.StringValue(target.Node),
+						TargetIndex: types.Int64Value(target.Index),
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(connections, func(i, j int) bool {
+		a, b := connections[i], connections[j]
+		if a.SourceNode.ValueString() != b.SourceNode.ValueString() {
+			return a.SourceNode.ValueString() < b.SourceNode.ValueString()
+		}
+		if a.OutputType.ValueString() != b.OutputType.ValueString() {
+			return a.OutputType.ValueString() < b.OutputType.ValueString()
+		}
+		if a.OutputIndex.ValueInt64() != b.OutputIndex.ValueInt64() {
+			return a.OutputIndex.ValueInt64() < b.OutputIndex.ValueInt64()
+		}
+		if a.TargetNode.ValueString() != b.TargetNode.ValueString() {
+			return a.TargetNode.ValueString() < b.TargetNode.ValueString()
+		}
+		return a.TargetIndex.ValueInt64() < b.TargetIndex.ValueInt64()
+	})
+
+	return connections, nil
+}
+
+// expandConnections is the inverse of flattenConnections. The target input
+// type always matches the output type, as it does in the n8n editor.
+func expandConnections(connections []connection) map[string]interface{} {
+	result := map[string]interface{}{}
+
+	grouped := map[string]map[string][][]apiConnection{}
+	for _, c := range connections {
+		sourceNode := c.SourceNode.ValueString()
+		outputType := c.OutputType.ValueString()
+		outputIndex := int(c.OutputIndex.ValueInt64())
+
+		if grouped[sourceNode] == nil {
+			grouped[sourceNode] = map[string][][]apiConnection{}
+		}
+		outputs := grouped[sourceNode][outputType]
+		for len(outputs) <= outputIndex {
+			outputs = append(outputs, []apiConnection{})
+		}
+		outputs[outputIndex] = append(outputs[outputIndex], apiConnection{
+			Node:  c.TargetNode.ValueString(),
+			Type:  outputType,
+			Index: c.TargetIndex.ValueInt64(),
+		})
+		grouped[sourceNode][outputType] = outputs
+	}
+
+	for sourceNode, outputs := range grouped {
+		result[sourceNode] = outputs
+	}
+
+	return result
+}
+
+// flattenProject maps an n8n API project to the Terraform model.
+func flattenProject(p *n8n.Project) project {
+	return project{
+		ID:   types.StringPointerValue(p.Id),
+		Name: types.StringValue(p.Name),
+		Type: types.StringPointerValue(p.Type),
+	}
+}
+
+// flattenUser maps an n8n API user to the Terraform model.
+func flattenUser(u *n8n.User) user {
+	return user{
+		ID:        types.StringPointerValue(u.Id),
+		Email:     types.StringValue(string(u.Email)),
+		FirstName: types.StringPointerValue(u.FirstName),
+		LastName:  types.StringPointerValue(u.LastName),
+		Role:      types.StringPointerValue(u.Role),
+		IsPending: types.BoolPointerValue(u.IsPending),
+		CreatedAt: convertTimeToTypesString(u.CreatedAt),
+		UpdatedAt: convertTimeToTypesString(u.UpdatedAt),
+	}
+}
+
+// flattenExecution maps an n8n API execution to the Terraform model.
+func flattenExecution(e *apiExecution) execution {
+	return execution{
+		ID:         types.StringValue(string(e.ID)),
+		WorkflowID: types.StringValue(string(e.WorkflowID)),
+		Status:     types.StringValue(e.Status),
+		Mode:       types.StringValue(e.Mode),
+		Finished:   types.BoolValue(e.Finished),
+		RetryOf:    convertStringToTypesString(string(e.RetryOf)),
+		StartedAt:  convertTimeToTypesString(e.StartedAt),
+		StoppedAt:  convertTimeToTypesString(e.StoppedAt),
+	}
+}
+
+// executionErrorMessage returns the error of a failed execution, including
+// the node it occurred in. The execution must include its data.
+func executionErrorMessage(e *apiExecution) string {
+	var data struct {
+		ResultData struct {
+			Error *struct {
+				Message     string `json:"message"`
+				Description string `json:"description"`
+				Node        *struct {
+					Name string `json:"name"`
+				} `json:"node"`
+			} `json:"error"`
+			LastNodeExecuted string `json:"lastNodeExecuted"`
+		} `json:"resultData"`
+	}
+	if len(e.Data) == 0 || json.Unmarshal(e.Data, &data) != nil || data.ResultData.Error == nil {
+		return fmt.Sprintf("execution %s ended with status %s", e.ID, e.Status)
+	}
+
+	execErr := data.ResultData.Error
+	message := execErr.Message
+	if execErr.Description != "" {
+		message += ": " + execErr.Description
+	}
+
+	node := data.ResultData.LastNodeExecuted
+	if execErr.Node != nil && execErr.Node.Name != "" {
+		node = execErr.Node.Name
+	}
+	if node != "" {
+		return fmt.Sprintf("node %q: %s", node, message)
+	}
+
+	return message
+}
+
+// flattenAuditReports maps n8n audit reports to the Terraform model.
+func flattenAuditReports(reports []auditReport) []auditReportModel {
+	models := make([]auditReportModel, len(reports))
+	for i, report := range reports {
+		sections := make([]auditSectionModel, len(report.Sections))
+		for j, section := range report.Sections {
+			locations := make([]auditLocationModel, len(section.Location))
+			for k, l := range section.Location {
+				locations[k] = auditLocationModel{
+					Kind:         convertStringToTypesString(l.Kind),
+					ID:           convertStringToTypesString(string(l.ID)),
+					Name:         convertStringToTypesString(l.Name),
+					WorkflowID:   convertStringToTypesString(string(l.WorkflowID)),
+					WorkflowName: convertStringToTypesString(l.WorkflowName),
+					NodeID:       convertStringToTypesString(string(l.NodeID)),
+					NodeName:     convertStringToTypesString(l.NodeName),
+					NodeType:     convertStringToTypesString(l.NodeType),
+				}
+			}
+			sections[j] = auditSectionModel{
+				Title:          types.StringValue(section.Title),
+				Description:    types.StringValue(section.Description),
+				Recommendation: types.StringValue(section.Recommendation),
+				Locations:      locations,
+			}
+		}
+		models[i] = auditReportModel{
+			Risk:     types.StringValue(report.Risk),
+			Sections: sections,
+		}
+	}
+
+	return models
+}
+
+// flattenImportResult records the result of a pull on the Terraform model.
+func flattenImportResult(result *n8n.ImportResult, state *sourceControlPullResourceModel) {
+	state.Workflows = []sourceControlPulledWorkflow{}
+	if result.Workflows != nil {
+		for _, w := range *result.Workflows {
+			state.Workflows = append(state.Workflows, sourceControlPulledWorkflow{
+				ID:   types.StringPointerValue(w.Id),
+				Name: types.StringPointerValue(w.Name),
+			})
+		}
+	}
+
+	state.Credentials = []sourceControlPulledCredential{}
+	if result.Credentials != nil {
+		for _, c := range *result.Credentials {
+			state.Credentials = append(state.Credentials, sourceControlPulledCredential{
+				ID:   types.StringPointerValue(c.Id),
+				Name: types.StringPointerValue(c.Name),
+				Type: types.StringPointerValue(c.Type),
+			})
+		}
+	}
+
+	state.Tags = []sourceControlPulledTag{}
+	if result.Tags != nil && result.Tags.Tags != nil {
+		for _, t := range *result.Tags.Tags {
+			state.Tags = append(state.Tags, sourceControlPulledTag{
+				ID:   types.StringPointerValue(t.Id),
+				Name: types.StringPointerValue(t.Name),
+			})
+		}
+	}
+
+	state.VariablesAdded = []types.String{}
+	state.VariablesChanged = []types.String{}
+	if result.Variables != nil {
+		if result.Variables.Added != nil {
+			for _, key := range *result.Variables.Added {
+				state.VariablesAdded = append(state.VariablesAdded, types.StringValue(key))
+			}
+		}
+		if result.Variables.Changed != nil {
+			for _, key := range *result.Variables.Changed {
+				state.VariablesChanged = append(state.VariablesChanged, types.StringValue(key))
+			}
+		}
+	}
+}
+
+// flattenVariable maps an n8n API variable to the Terraform model.
+func flattenVariable(v *n8n.Variable) variable {
+	return variable{
+		ID:    types.StringPointerValue(v.Id),
+		Key:   types.StringValue(v.Key),
+		Value: types.StringValue(v.Value),
+		Type:  types.StringPointerValue(v.Type),
+	}
+}
+
+// flattenTags maps n8n API tags to the Terraform model.
+func flattenTags(apiTags []n8n.Tag) []tag {
+	tags := make([]tag, len(apiTags))
+	for i, t := range apiTags {
+		tags[i] = flattenTag(&t)
+	}
+
+	return tags
+}
+
+// flattenTag maps an n8n API tag to the Terraform model.
+func flattenTag(t *n8n.Tag) tag {
+	return tag{
+		ID:        types.StringPointerValue(t.Id),
+		Name:      types.StringValue(t.Name),
+		CreatedAt: convertTimeToTypesString(t.CreatedAt),
+		UpdatedAt: convertTimeToTypesString(t.UpdatedAt),
+	}
+}
+
+// expandWorkflow maps the Terraform model to an n8n API workflow.
+func expandWorkflow(wfModel *workflowDataSourceModel) (*n8n.Workflow, error) {
+	workflow := n8n.Workflow{
+		Name:        wfModel.Name.ValueString(),
+		Nodes:       make([]n8n.Node, len(wfModel.Nodes)),
+		Connections: map[string]interface{}{},
+	}
+
+	// Map Nodes
+	for i, n := range wfModel.Nodes {
+		parameters, err := convertJSONToMap(n.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert parameters of node %q: %w", n.Name.ValueString(), err)
+		}
+		credentials, err := convertJSONToMap(n.Credentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert credentials of node %q: %w", n.Name.ValueString(), err)
+		}
+
+		position := make([]float32, len(n.Position))
+		for j, p := range n.Position {
+			position[j] = float32(p.ValueInt64())
+		}
+
+		workflow.Nodes[i] = n8n.Node{
+			Id:               convertTypesStringToPointer(n.ID),
+			Name:             convertTypesStringToPointer(n.Name),
+			WebhookId:        convertTypesStringToPointer(n.WebhookID),
+			Disabled:         convertTypesBoolToPointer(n.Disabled),
+			NotesInFlow:      convertTypesBoolToPointer(n.NotesInFlow),
+			Notes:            convertTypesStringToPointer(n.Notes),
+			Type:             convertTypesStringToPointer(n.Type),
+			TypeVersion:      convertTypesFloat64ToFloat32(n.TypeVersion),
+			ExecuteOnce:      convertTypesBoolToPointer(n.ExecuteOnce),
+			AlwaysOutputData: convertTypesBoolToPointer(n.AlwaysOutputData),
+			RetryOnFail:      convertTypesBoolToPointer(n.RetryOnFail),
+			MaxTries:         convertTypesInt64ToFloat32(n.MaxTries),
+			WaitBetweenTries: convertTypesInt64ToFloat32(n.WaitBetweenTries),
+			ContinueOnFail:   convertTypesBoolToPointer(n.ContinueOnFail),
+			OnError:          convertTypesStringToPointer(n.OnError),
+			Position:         &position,
+			Parameters:       parameters,
+			Credentials:      credentials,
+		}
+	}
+
+	// Map Connections
+	workflow.Connections = expandConnections(wfModel.Connections)
+
+	// Map Settings
+	if s := wfModel.Settings; s != nil {
+		workflow.Settings = n8n.WorkflowSettings{
+			SaveExecutionProgress:    convertTypesBoolToPointer(s.SaveExecutionProgress),
+			SaveManualExecutions:     convertTypesBoolToPointer(s.SaveManualExecutions),
+			SaveDataErrorExecution:   (*n8n.WorkflowSettingsSaveDataErrorExecution)(convertTypesStringToPointer(s.SaveDataErrorExecution)),
+			SaveDataSuccessExecution: (*n8n.WorkflowSettingsSaveDataSuccessExecution)(convertTypesStringToPointer(s.SaveDataSuccessExecution)),
+			ExecutionTimeout:         convertTypesInt64ToFloat32(s.ExecutionTimeout),
+			ErrorWorkflow:            convertTypesStringToPointer(s.ErrorWorkflow),
+			Timezone:                 convertTypesStringToPointer(s.Timezone),
+			ExecutionOrder:           convertTypesStringToPointer(s.ExecutionOrder),
+		}
+	}
+
+	// Map Static Data
+	if !wfModel.StaticData.IsNull() && !wfModel.StaticData.IsUnknown() {
+		var staticData n8n.Workflow_StaticData
+		if err := staticData.UnmarshalJSON([]byte(wfModel.StaticData.ValueString())); err != nil {
+			return nil, fmt.Errorf("failed to convert static data: %w", err)
+		}
+		workflow.StaticData = &staticData
+	}
+
+	return &workflow, nil
+}
+
+// convertStringToTypesString maps an empty API string to null.
+func convertStringToTypesString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(s)
+}
+
+// convertTimeToTypesString formats an optional API timestamp as RFC 3339.
+func convertTimeToTypesString(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(t.Format(time.RFC3339))
+}
+
+// convertFloat32ToTypesInt64 converts an optional API number to a whole number.
+func convertFloat32ToTypesInt64(f *float32) types.Int64 {
+	if f == nil {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(int64(*f))
+}
+
+// convertFloat32ToTypesFloat64 converts an optional API number without
+// introducing float32 rounding artifacts (e.g. 1.1 stays 1.1).
+func convertFloat32ToTypesFloat64(f *float32) types.Float64 {
+	if f == nil {
+		return types.Float64Null()
+	}
+
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(float64(*f), 'f', -1, 32), 64)
+
+	return types.Float64Value(v)
+}
+
+// convertTypesInt64ToFloat32 converts a Terraform whole number to an optional API number.
+func convertTypesInt64ToFloat32(v types.Int64) *float32 {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	f := float32(v.ValueInt64())
+
+	return &f
+}
+
+// convertTypesStringToPointer converts a Terraform string to an optional API
+// string. Unknown values are left out, so n8n keeps or generates them.
+func convertTypesStringToPointer(v types.String) *string {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	return v.ValueStringPointer()
+}
+
+// convertTypesBoolToPointer converts a Terraform bool to an optional API bool.
+func convertTypesBoolToPointer(v types.Bool) *bool {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	return v.ValueBoolPointer()
+}
+
+// convertTypesFloat64ToFloat32 converts a Terraform number to an optional API number.
+func convertTypesFloat64ToFloat32(v types.Float64) *float32 {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	f := float32(v.ValueFloat64())
+
+	return &f
+}
+
+// convertInt64SliceToTypesInt64Slice converts an API node position to Terraform values.
+func convertInt64SliceToTypesInt64Slice(s *[]float32) []types.Int64 {
+	if s == nil {
+		return nil
+	}
+
+	result := make([]types.Int64, len(*s))
+	for i, v := range *s {
+		result[i] = types.Int64Value(int64(v))
+	}
+
+	return result
+}
+
+// convertMapToJSON converts a free-form API object, such as node parameters,
+// to a normalized JSON string so nested values keep their types.
+func convertMapToJSON(m *map[string]interface{}) (jsontypes.Normalized, error) {
+	if m == nil {
+		return jsontypes.NewNormalizedNull(), nil
+	}
+
+	encoded, err := json.Marshal(m)
+	if err != nil {
+		return jsontypes.NewNormalizedNull(), err
+	}
+
+	return jsontypes.NewNormalizedValue(string(encoded)), nil
+}
+
+// convertJSONToMap is the inverse of convertMapToJSON.
+func convertJSONToMap(v jsontypes.Normalized) (*map[string]interface{}, error) {
+	if v.IsNull() || v.IsUnknown() {
+		return nil, nil
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(v.ValueString()), &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,715 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edenreich/n8n-cli/n8n"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testWorkflowJSON = `{
+  "id": "wf-1",
+  "name": "Example",
+  "active": false,
+  "createdAt": "2025-01-02T03:04:05Z",
+  "updatedAt": "2025-01-02T03:04:05Z",
+  "nodes": [
+    {
+      "id": "node-1",
+      "name": "Manual Trigger",
+      "type": "n8n-nodes-base.manualTrigger",
+      "typeVersion": 1,
+      "position": [0, 0],
+      "parameters": {}
+    },
+    {
+      "id": "node-2",
+      "name": "Set",
+      "type": "n8n-nodes-base.set",
+      "typeVersion": 3.4,
+      "position": [220, 0],
+      "parameters": {"mode": "raw", "jsonOutput": {"a": 1}, "includeOtherFields": true}
+    }
+  ],
+  "connections": {
+    "Manual Trigger": {"main": [[{"node": "Set", "type": "main", "index": 0}]]}
+  },
+  "settings": {"executionOrder": "v1", "saveDataErrorExecution": "all"},
+  "staticData": {"lastId": 3},
+  "tags": [{"id": "tag-1", "name": "prod"}]
+}`
+
+func TestFlattenWorkflow(t *testing.T) {
+	var workflow n8n.Workflow
+	if err := json.Unmarshal([]byte(testWorkflowJSON), &workflow); err != nil {
+		t.Fatal(err)
+	}
+
+	wfModel, err := flattenWorkflow(&workflow)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := wfModel.CreatedAt.ValueString(); got != "2025-01-02T03:04:05Z" {
+		t.Errorf("created_at = %q", got)
+	}
+	if got := wfModel.Nodes[1].TypeVersion.ValueFloat64(); got != 3.4 {
+		t.Errorf("type_version = %v, want 3.4", got)
+	}
+	if got := wfModel.Nodes[1].Parameters.ValueString(); got != `{"includeOtherFields":true,"jsonOutput":{"a":1},"mode":"raw"}` {
+		t.Errorf("parameters = %s", got)
+	}
+	if !wfModel.Nodes[0].Credentials.IsNull() {
+		t.Errorf("credentials = %s, want null", wfModel.Nodes[0].Credentials)
+	}
+	if got := wfModel.Settings.SaveDataErrorExecution.ValueString(); got != "all" {
+		t.Errorf("save_data_error_execution = %q", got)
+	}
+	if got := wfModel.StaticData.ValueString(); got != `{"lastId":3}` {
+		t.Errorf("static_data = %q", got)
+	}
+	if len(wfModel.Tags) != 1 || wfModel.Tags[0].ID.ValueString() != "tag-1" {
+		t.Errorf("tags = %v", wfModel.Tags)
+	}
+}
+
+func TestExpandWorkflowRoundTrip(t *testing.T) {
+	var workflow n8n.Workflow
+	if err := json.Unmarshal([]byte(testWorkflowJSON), &workflow); err != nil {
+		t.Fatal(err)
+	}
+
+	wfModel, err := flattenWorkflow(&workflow)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expanded, err := expandWorkflow(wfModel)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, want := toGeneric(t, expanded.Connections), toGeneric(t, workflow.Connections); !reflect.DeepEqual(got, want) {
+		t.Errorf("connections = %v, want %v", got, want)
+	}
+	for i := range workflow.Nodes {
+		if !reflect.DeepEqual(expanded.Nodes[i].Parameters, workflow.Nodes[i].Parameters) {
+			t.Errorf("node %d parameters = %v, want %v", i, *expanded.Nodes[i].Parameters, *workflow.Nodes[i].Parameters)
+		}
+		if *expanded.Nodes[i].TypeVersion != *workflow.Nodes[i].TypeVersion {
+			t.Errorf("node %d type_version = %v, want %v", i, *expanded.Nodes[i].TypeVersion, *workflow.Nodes[i].TypeVersion)
+		}
+	}
+	if !reflect.DeepEqual(expanded.Settings, workflow.Settings) {
+		t.Errorf("settings = %+v, want %+v", expanded.Settings, workflow.Settings)
+	}
+}
+
+func TestExpandWorkflowOmitsUnknownValues(t *testing.T) {
+	var workflow n8n.Workflow
+	if err := json.Unmarshal([]byte(testWorkflowJSON), &workflow); err != nil {
+		t.Fatal(err)
+	}
+
+	wfModel, err := flattenWorkflow(&workflow)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Computed attributes the configuration does not set are unknown in the plan
+	wfModel.WorkflowJSON = newWorkflowJSONNull()
+	wfModel.Nodes[0].ID = types.StringUnknown()
+	wfModel.Nodes[0].WebhookID = types.StringUnknown()
+	wfModel.Nodes[0].Disabled = types.BoolUnknown()
+	wfModel.Nodes[0].Notes = types.StringUnknown()
+	wfModel.Settings.SaveDataErrorExecution = types.StringUnknown()
+	wfModel.Settings.ExecutionOrder = types.StringUnknown()
+	wfModel.Settings.SaveManualExecutions = types.BoolUnknown()
+
+	expanded, err := expandWorkflow(wfModel)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	encoded, err := json.Marshal(expanded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		Nodes    []map[string]interface{} `json:"nodes"`
+		Settings map[string]interface{}   `json:"settings"`
+	}
+	if err := json.Unmarshal(encoded, &body); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"id", "webhookId", "disabled", "notes"} {
+		if value, ok := body.Nodes[0][key]; ok {
+			t.Errorf("node %s = %v, want omitted", key, value)
+		}
+	}
+	for _, key := range []string{"saveDataErrorExecution", "executionOrder", "saveManualExecutions"} {
+		if value, ok := body.Settings[key]; ok {
+			t.Errorf("settings %s = %v, want omitted", key, value)
+		}
+	}
+	if got := body.Nodes[1]["id"]; got != "node-2" {
+		t.Errorf("known node id = %v, want node-2", got)
+	}
+}
+
+func TestFlattenConnections(t *testing.T) {
+	apiConnections := map[string]interface{}{}
+	err := json.Unmarshal([]byte(`{
+  "IF": {"main": [[{"node": "True", "type": "main", "index": 0}], [{"node": "False", "type": "main", "index": 0}]]},
+  "Trigger": {"main": [[{"node": "IF", "type": "main", "index": 0}, {"node": "Merge", "type": "main", "index": 1}]]}
+}`), &apiConnections)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	connections, err := flattenConnections(apiConnections)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"IF/main/0 -> True/0",
+		"IF/main/1 -> False/0",
+		"Trigger/main/0 -> IF/0",
+		"Trigger/main/0 -> Merge/1",
+	}
+	if len(connections) != len(expected) {
+		t.Fatalf("got %d connections, want %d", len(connections), len(expected))
+	}
+	for i, c := range connections {
+		got := fmt.Sprintf("%s/%s/%d -> %s/%d", c.SourceNode.ValueString(), c.OutputType.ValueString(), c.OutputIndex.ValueInt64(), c.TargetNode.ValueString(), c.TargetIndex.ValueInt64())
+		if got != expected[i] {
+			t.Errorf("connection %d = %s, want %s", i, got, expected[i])
+		}
+	}
+
+	if got, want := toGeneric(t, expandConnections(connections)), toGeneric(t, apiConnections); !reflect.DeepEqual(got, want) {
+		t.Errorf("expanded connections = %v, want %v", got, want)
+	}
+}
+
+// toGeneric re-decodes v so values of different Go types can be compared as JSON.
+func toGeneric(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	return decoded
+}
+
+func TestClientDoRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-N8N-API-KEY"); got != "secret" {
+			t.Errorf("X-N8N-API-KEY = %q", got)
+		}
+
+		switch r.URL.Path {
+		case "/api/v1/credentials":
+			var credential n8n.Credential
+			if err := json.NewDecoder(r.Body).Decode(&credential); err != nil {
+				t.Error(err)
+			}
+			_, _ = fmt.Fprintf(w, `{"id":"cred-1","name":%q,"type":%q}`, credential.Name, credential.Type)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+	created, err := c.createCredential(context.Background(), &n8n.Credential{Name: "Slack", Type: "slackApi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *created.Id != "cred-1" || created.Name != "Slack" {
+		t.Errorf("created = %+v", created)
+	}
+
+	err = c.deleteCredential(context.Background(), "missing")
+	if !isNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestClientListAllFollowsCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/tags" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","name":"dev"}],"nextCursor":"page-2"}`))
+		case "page-2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"2","name":"prod"}],"nextCursor":null}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+	tags, err := c.listTags(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tags) != 2 || tags[1].Name != "prod" {
+		t.Errorf("tags = %+v", tags)
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	err := &apiError{StatusCode: 400, Body: `{"message":"Workflow has no node to start the workflow"}`}
+	if got := err.Message(); got != "Workflow has no node to start the workflow" {
+		t.Errorf("Message() = %q", got)
+	}
+
+	err = &apiError{StatusCode: 502, Body: "Bad Gateway"}
+	if got := err.Message(); got != "Bad Gateway" {
+		t.Errorf("Message() = %q", got)
+	}
+}
+
+func TestClientListWorkflowsFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("active"); got != "true" {
+			t.Errorf("active = %q, want true", got)
+		}
+		if got := query.Get("tags"); got != "prod,billing" {
+			t.Errorf("tags = %q, want prod,billing", got)
+		}
+		if got := query.Get("projectId"); got != "" {
+			t.Errorf("projectId = %q, want empty", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"wf-1","name":"Example","active":true,"nodes":[],"connections":{},"settings":{}}],"nextCursor":null}`))
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+	active, tags := true, "prod,billing"
+	workflows, err := c.listWorkflows(context.Background(), n8n.GetWorkflowsParams{Active: &active, Tags: &tags})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(workflows) != 1 || *workflows[0].Id != "wf-1" {
+		t.Errorf("workflows = %+v", workflows)
+	}
+}
+
+func TestWorkflowDataSourceLookupWorkflowID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[
+  {"id":"wf-1","name":"Billing","nodes":[],"connections":{},"settings":{}},
+  {"id":"wf-2","name":"Billing (copy)","nodes":[],"connections":{},"settings":{}},
+  {"id":"wf-3","name":"Sync","nodes":[],"connections":{},"settings":{}},
+  {"id":"wf-4","name":"Sync","nodes":[],"connections":{},"settings":{}}
+],"nextCursor":null}`))
+	}))
+	defer server.Close()
+
+	d := &workflowDataSource{client: &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}}
+
+	testCases := map[string]struct {
+		name     string
+		expected string
+		errored  bool
+	}{
+		"exact match":   {name: "Billing", expected: "wf-1"},
+		"no match":      {name: "Reports", errored: true},
+		"several match": {name: "Sync", errored: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			id, diags := d.lookupWorkflowID(context.Background(), testCase.name)
+			if diags.HasError() != testCase.errored {
+				t.Fatalf("diagnostics = %v, want error %t", diags, testCase.errored)
+			}
+			if id != testCase.expected {
+				t.Errorf("id = %q, want %q", id, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestClientCreateVariableWithoutResponseBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"data":[{"id":"var-1","key":"OTHER","value":"x","type":"string"},{"id":"var-2","key":"API_URL","value":"https://example.com","type":"string"}],"nextCursor":null}`))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+	created, err := c.createVariable(context.Background(), "API_URL", "https://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *created.Id != "var-2" {
+		t.Errorf("id = %q, want var-2", *created.Id)
+	}
+}
+
+func TestClientCreateWorkflowTagsFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/workflows":
+			_, _ = w.Write([]byte(testWorkflowJSON))
+		case "/api/v1/workflows/wf-1/tags":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Tag not found"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+	var plan n8n.Workflow
+	if err := json.Unmarshal([]byte(testWorkflowJSON), &plan); err != nil {
+		t.Fatal(err)
+	}
+	planModel, err := flattenWorkflow(&plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The created workflow is returned, so it can be kept in state
+	created, err := c.createWorkflow(context.Background(), planModel)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if created == nil || created.ID.ValueString() != "wf-1" {
+		t.Errorf("created = %+v, want workflow wf-1", created)
+	}
+}
+
+func TestClientUpdateWorkflowTagsFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/workflows/wf-1":
+			if r.Method != http.MethodPut {
+				t.Errorf("method = %s, want PUT", r.Method)
+			}
+			_, _ = w.Write([]byte(testWorkflowJSON))
+		case "/api/v1/workflows/wf-1/tags":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Tag not found"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+	var plan n8n.Workflow
+	if err := json.Unmarshal([]byte(testWorkflowJSON), &plan); err != nil {
+		t.Fatal(err)
+	}
+	planModel, err := flattenWorkflow(&plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The updated workflow is returned, so the state can follow it
+	updated, err := c.updateWorkflow(context.Background(), "wf-1", planModel)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if updated == nil || updated.Name.ValueString() != "Example" {
+		t.Errorf("updated = %+v, want workflow Example", updated)
+	}
+}
+
+func TestClientTransferWorkflow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v1/workflows/wf-1/transfer" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if got := body["destinationProjectId"]; got != "project-1" {
+			t.Errorf("destinationProjectId = %q, want project-1", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+	if err := c.transferWorkflow(context.Background(), "wf-1", "project-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestClientGetProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"id":"project-1","name":"Billing","type":"team"}],"nextCursor":null}`))
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+	p, err := c.getProject(context.Background(), "project-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p == nil || p.Name != "Billing" {
+		t.Errorf("project = %+v", p)
+	}
+
+	p, err = c.getProject(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p != nil {
+		t.Errorf("project = %+v, want nil", p)
+	}
+}
+
+func TestClientInviteUser(t *testing.T) {
+	testCases := map[string]struct {
+		response string
+		errored  bool
+	}{
+		"invited": {
+			response: `[{"user":{"id":"user-1","email":"jane@example.com","inviteAcceptUrl":"https://n8n.example.com/signup?inviterId=1","emailSent":false},"error":""}]`,
+		},
+		"rejected": {
+			response: `[{"user":{"id":"","email":"jane@example.com"},"error":"Email already in use"}]`,
+			errored:  true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body []map[string]string
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				if len(body) != 1 || body[0]["role"] != "global:member" {
+					t.Errorf("body = %v", body)
+				}
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(testCase.response))
+			}))
+			defer server.Close()
+
+			c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+			invitation, err := c.inviteUser(context.Background(), "jane@example.com", "global:member")
+			if (err != nil) != testCase.errored {
+				t.Fatalf("error = %v, want error %t", err, testCase.errored)
+			}
+			if err == nil && invitation.User.ID != "user-1" {
+				t.Errorf("id = %q, want user-1", invitation.User.ID)
+			}
+		})
+	}
+}
+
+func TestClientListExecutionsLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("limit"); got != "3" {
+			t.Errorf("limit = %q, want 3", got)
+		}
+		if got := query.Get("status"); got != "error" {
+			t.Errorf("status = %q, want error", got)
+		}
+
+		switch query.Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":7,"workflowId":"wf-1","status":"error"},{"id":"6","workflowId":"wf-1","status":"error","retryOf":"5"}],"nextCursor":"page-2"}`))
+		case "page-2":
+			_, _ = w.Write([]byte(`{"data":[{"id":5,"workflowId":"wf-1","status":"error"},{"id":4,"workflowId":"wf-1","status":"error"}],"nextCursor":"page-3"}`))
+		default:
+			t.Errorf("unexpected cursor %q", query.Get("cursor"))
+		}
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+	executions, err := c.listExecutions(context.Background(), executionFilter{Status: "error", Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(executions) != 3 {
+		t.Fatalf("got %d executions, want 3", len(executions))
+	}
+	if executions[0].ID != "7" || executions[1].RetryOf != "5" {
+		t.Errorf("executions = %+v", executions)
+	}
+	if got := flattenExecution(&executions[0]).RetryOf; !got.IsNull() {
+		t.Errorf("retry_of = %s, want null", got)
+	}
+}
+
+func TestClientRunAudit(t *testing.T) {
+	testCases := map[string]struct {
+		response string
+		expected []string
+	}{
+		"no findings": {
+			response: `[]`,
+			expected: []string{},
+		},
+		"findings": {
+			response: `{
+  "Nodes Risk Report": {"risk": "nodes", "sections": [{"title": "Community nodes", "description": "d", "recommendation": "r", "location": [{"kind": "community", "nodeType": "n8n-nodes-foo.bar"}]}]},
+  "Credentials Risk Report": {"risk": "credentials", "sections": [{"title": "Credentials not used in any workflow", "description": "d", "recommendation": "r", "location": [{"kind": "credential", "id": 1, "name": "Old"}]}]}
+}`,
+			expected: []string{"credentials", "nodes"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					AdditionalOptions map[string]interface{} `json:"additionalOptions"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				if got := body.AdditionalOptions["daysAbandonedWorkflow"]; got != float64(30) {
+					t.Errorf("daysAbandonedWorkflow = %v, want 30", got)
+				}
+				_, _ = w.Write([]byte(testCase.response))
+			}))
+			defer server.Close()
+
+			c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+			reports, err := c.runAudit(context.Background(), []string{"credentials", "nodes"}, 30)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			risks := []string{}
+			for _, report := range reports {
+				risks = append(risks, report.Risk)
+			}
+			if !reflect.DeepEqual(risks, testCase.expected) {
+				t.Errorf("risks = %v, want %v", risks, testCase.expected)
+			}
+			if len(reports) > 0 && reports[0].Sections[0].Location[0].ID != "1" {
+				t.Errorf("location = %+v", reports[0].Sections[0].Location[0])
+			}
+		})
+	}
+}
+
+func TestFlattenImportResult(t *testing.T) {
+	var result n8n.ImportResult
+	err := json.Unmarshal([]byte(`{
+  "workflows": [{"id": "wf-1", "name": "Billing"}],
+  "credentials": [{"id": "cred-1", "name": "Stripe", "type": "stripeApi"}],
+  "variables": {"added": ["API_URL"], "changed": []},
+  "tags": {"tags": [{"id": "tag-1", "name": "prod"}], "mappings": [{"workflowId": "wf-1", "tagId": "tag-1"}]}
+}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var state sourceControlPullResourceModel
+	flattenImportResult(&result, &state)
+
+	if len(state.Workflows) != 1 || state.Workflows[0].Name.ValueString() != "Billing" {
+		t.Errorf("workflows = %v", state.Workflows)
+	}
+	if len(state.Credentials) != 1 || state.Credentials[0].Type.ValueString() != "stripeApi" {
+		t.Errorf("credentials = %v", state.Credentials)
+	}
+	if len(state.Tags) != 1 || state.Tags[0].ID.ValueString() != "tag-1" {
+		t.Errorf("tags = %v", state.Tags)
+	}
+	if len(state.VariablesAdded) != 1 || state.VariablesChanged == nil || len(state.VariablesChanged) != 0 {
+		t.Errorf("variables added = %v, changed = %v", state.VariablesAdded, state.VariablesChanged)
+	}
+}
+
+func TestClientGetCredentialSchemaConcurrent(t *testing.T) {
+	fastDone := make(chan struct{})
+	var fastCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/credentials/schema/slowApi":
+			// Only answers once the other type has been fetched
+			select {
+			case <-fastDone:
+			case <-time.After(5 * time.Second):
+				t.Error("lookup of fastApi waited for slowApi")
+			}
+		case "/api/v1/credentials/schema/fastApi":
+			atomic.AddInt32(&fastCalls, 1)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"type":"object","properties":{}}`))
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}
+
+	slowErr := make(chan error, 1)
+	go func() {
+		_, err := c.getCredentialSchema(context.Background(), "slowApi")
+		slowErr <- err
+	}()
+
+	// Wait for the slow lookup to reach the server
+	time.Sleep(50 * time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.getCredentialSchema(context.Background(), "fastApi"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	close(fastDone)
+
+	if err := <-slowErr; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fastCalls != 1 {
+		t.Errorf("fastApi fetched %d times, want 1", fastCalls)
+	}
+}
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// auditCategories are the risk categories the security audit can check.
+var auditCategories = []string{"credentials", "database", "nodes", "filesystem", "instance"}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &auditDataSource{}
+	_ datasource.DataSourceWithConfigure      = &auditDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &auditDataSource{}
+)
+
+// NewAuditDataSource is a helper function to simplify the provider implementation.
+func NewAuditDataSource() datasource.DataSource {
+	return &auditDataSource{}
+}
+
+// auditDataSource is the data source implementation.
+type auditDataSource struct {
+	client *client
+}
+
+// auditDataSourceModel maps the data source schema data.
+type auditDataSourceModel struct {
+	Categories            []types.String     `tfsdk:"categories"`
+	DaysAbandonedWorkflow types.Int64        `tfsdk:"days_abandoned_workflow"`
+	Reports               []auditReportModel `tfsdk:"reports"`
+	FindingCount          types.Int64        `tfsdk:"finding_count"`
+}
+
+// auditReportModel maps a risk report.
+type auditReportModel struct {
+	Risk     types.String        `tfsdk:"risk"`
+	Sections []auditSectionModel `tfsdk:"sections"`
+}
+
+// auditSectionModel maps a section of a risk report.
+type auditSectionModel struct {
+	Title          types.String         `tfsdk:"title"`
+	Description    types.String         `tfsdk:"description"`
+	Recommendation types.String         `tfsdk:"recommendation"`
+	Locations      []auditLocationModel `tfsdk:"locations"`
+}
+
+// auditLocationModel maps a single finding.
+type auditLocationModel struct {
+	Kind         types.String `tfsdk:"kind"`
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	WorkflowID   types.String `tfsdk:"workflow_id"`
+	WorkflowName types.String `tfsdk:"workflow_name"`
+	NodeID       types.String `tfsdk:"node_id"`
+	NodeName     types.String `tfsdk:"node_name"`
+	NodeType     types.String `tfsdk:"node_type"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *auditDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *auditDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audit"
+}
+
+// Schema defines the schema for the data source.
+func (d *auditDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs the security audit of the n8n instance.",
+		Attributes: map[string]schema.Attribute{
+			"categories": schema.ListAttribute{
+				Description: "The risk categories to audit, any of `credentials`, `database`, `nodes`, `filesystem` and `instance`. Audits all categories if not set.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"days_abandoned_workflow": schema.Int64Attribute{
+				Description: "The number of days without executions after which a workflow is considered abandoned, at least 1. Uses the n8n default if not set.",
+				Optional:    true,
+			},
+			"reports": schema.ListNestedAttribute{
+				Description: "The risk reports, one per category with findings.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"risk": schema.StringAttribute{
+							Description: "The risk category of the report.",
+							Computed:    true,
+						},
+						"sections": schema.ListNestedAttribute{
+							Description: "The groups of findings of the report.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"title": schema.StringAttribute{
+										Description: "The title of the section.",
+										Computed:    true,
+									},
+									"description": schema.StringAttribute{
+										Description: "What the findings of the section mean.",
+										Computed:    true,
+									},
+									"recommendation": schema.StringAttribute{
+										Description: "How to resolve the findings.",
+										Computed:    true,
+									},
+									"locations": schema.ListNestedAttribute{
+										Description: "The findings. Which attributes are set depends on the kind.",
+										Computed:    true,
+										NestedObject: schema.NestedAttributeObject{
+											Attributes: map[string]schema.Attribute{
+												"kind": schema.StringAttribute{
+													Description: "What the finding points at, e.g. `credential`, `node` or `community`.",
+													Computed:    true,
+												},
+												"id": schema.StringAttribute{
+													Description: "The ID of the credential.",
+													Computed:    true,
+												},
+												"name": schema.StringAttribute{
+													Description: "The name of the credential or package.",
+													Computed:    true,
+												},
+												"workflow_id": schema.StringAttribute{
+													Description: "The ID of the workflow.",
+													Computed:    true,
+												},
+												"workflow_name": schema.StringAttribute{
+													Description: "The name of the workflow.",
+													Computed:    true,
+												},
+												"node_id": schema.StringAttribute{
+													Description: "The ID of the node.",
+													Computed:    true,
+												},
+												"node_name": schema.StringAttribute{
+													Description: "The name of the node.",
+													Computed:    true,
+												},
+												"node_type": schema.StringAttribute{
+													Description: "The type of the node.",
+													Computed:    true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"finding_count": schema.Int64Attribute{
+				Description: "The total number of findings across all reports. A section without locations counts as one finding.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures only known categories are audited and the number
+// of days is positive.
+func (d *auditDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var daysAbandonedWorkflow types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("days_abandoned_workflow"), &daysAbandonedWorkflow)...)
+	if !daysAbandonedWorkflow.IsNull() && !daysAbandonedWorkflow.IsUnknown() && daysAbandonedWorkflow.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("days_abandoned_workflow"),
+			"Invalid Days Abandoned Workflow",
+			fmt.Sprintf("The number of days must be at least 1, got %d.", daysAbandonedWorkflow.ValueInt64()),
+		)
+	}
+
+	var categories types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("categories"), &categories)...)
+	if resp.Diagnostics.HasError() || categories.IsNull() || categories.IsUnknown() {
+		return
+	}
+
+	for i, element := range categories.Elements() {
+		category, ok := element.(types.String)
+		if !ok || category.IsNull() || category.IsUnknown() {
+			continue
+		}
+
+		known := false
+		for _, auditCategory := range auditCategories {
+			known = known || category.ValueString() == auditCategory
+		}
+		if !known {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("categories").AtListIndex(i),
+				"Invalid Audit Category",
+				fmt.Sprintf("The category must be one of %s, got %q.", strings.Join(auditCategories, ", "), category.ValueString()),
+			)
+		}
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *auditDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredClientError(&resp.Diagnostics)
+		return
+	}
+
+	var state auditDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	categories := make([]string, len(state.Categories))
+	for i, category := range state.Categories {
+		categories[i] = category.ValueString()
+	}
+
+	reports, err := d.client.runAudit(ctx, categories, int(state.DaysAbandonedWorkflow.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Run n8n Audit",
+			err.Error(),
+		)
+		return
+	}
+
+	findings := 0
+	for _, report := range reports {
+		for _, section := range report.Sections {
+			// Instance sections, e.g. about outdated versions, have no locations
+			findings += max(len(section.Location), 1)
+		}
+	}
+	state.Reports = flattenAuditReports(reports)
+	state.FindingCount = types.Int64Value(int64(findings))
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
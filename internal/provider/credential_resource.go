@@ -0,0 +1,345 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edenreich/n8n-cli/n8n"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &credentialResource{}
+	_ resource.ResourceWithConfigure      = &credentialResource{}
+	_ resource.ResourceWithValidateConfig = &credentialResource{}
+	_ resource.ResourceWithModifyPlan     = &credentialResource{}
+)
+
+// NewCredentialResource is a helper function to simplify the provider implementation.
+func NewCredentialResource() resource.Resource {
+	return &credentialResource{}
+}
+
+// credentialResource is the resource implementation.
+type credentialResource struct {
+	client *client
+}
+
+// credentialResourceModel maps the resource schema data.
+type credentialResourceModel struct {
+	ID            types.String         `tfsdk:"id"`
+	Name          types.String         `tfsdk:"name"`
+	Type          types.String         `tfsdk:"type"`
+	Data          jsontypes.Normalized `tfsdk:"data"`
+	DataWO        jsontypes.Normalized `tfsdk:"data_wo"`
+	DataWOVersion types.Int64          `tfsdk:"data_wo_version"`
+	ProjectID     types.String         `tfsdk:"project_id"`
+	CreatedAt     types.String         `tfsdk:"created_at"`
+	UpdatedAt     types.String         `tfsdk:"updated_at"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *credentialResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *credentialResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credential"
+}
+
+// Schema defines the schema for the resource.
+func (r *credentialResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a credential. n8n does not return credential data, so any change other than `project_id` replaces the credential.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Credential ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the credential.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "The credential type, e.g. `slackApi` or `postgres`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"data": schema.StringAttribute{
+				Description: "The credential data as JSON. Stored in state; prefer `data_wo` on Terraform 1.11 and later. Conflicts with `data_wo`.",
+				CustomType:  jsontypes.NormalizedType{},
+				Optional:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"data_wo": schema.StringAttribute{
+				Description: "The credential data as JSON, never stored in state. Change `data_wo_version` to apply new data. Conflicts with `data`.",
+				CustomType:  jsontypes.NormalizedType{},
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"data_wo_version": schema.Int64Attribute{
+				Description: "Arbitrary version of `data_wo`. Changing it replaces the credential with the current `data_wo`.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Description: "The project the credential belongs to. Defaults to the personal project of the API key owner. Changing it transfers the credential; removing it leaves the credential where it is.",
+				Optional:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The creation date of the credential.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "The last update date of the credential.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures exactly one of data and data_wo is configured.
+func (r *credentialResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config credentialResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case config.Data.IsNull() && config.DataWO.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("data_wo"),
+			"Missing Credential Data",
+			"Either data or data_wo must be configured.",
+		)
+	case !config.Data.IsNull() && !config.DataWO.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("data_wo"),
+			"Conflicting Credential Data",
+			"Only one of data and data_wo can be configured.",
+		)
+	case !config.DataWOVersion.IsNull() && config.DataWO.IsNull():
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("data_wo_version"),
+			"Unused Credential Data Version",
+			"data_wo_version only has an effect together with data_wo.",
+		)
+	}
+}
+
+// ModifyPlan validates the credential data against the schema of its
+// credential type before anything is applied.
+func (r *credentialResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var config credentialResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, dataPath := config.Data, path.Root("data")
+	if !config.DataWO.IsNull() {
+		data, dataPath = config.DataWO, path.Root("data_wo")
+	}
+	if config.Type.IsUnknown() || data.IsNull() || data.IsUnknown() {
+		return
+	}
+
+	var credentialData map[string]interface{}
+	if diags := data.Unmarshal(&credentialData); diags.HasError() {
+		resp.Diagnostics.AddAttributeError(
+			dataPath,
+			"Invalid Credential Data",
+			"The credential data must be a JSON object.",
+		)
+		return
+	}
+
+	credentialSchema, err := r.client.getCredentialSchema(ctx, config.Type.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("type"),
+				"Unknown Credential Type",
+				fmt.Sprintf("n8n does not know the credential type %q.", config.Type.ValueString()),
+			)
+			return
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("type"),
+			"Unable to Validate n8n Credential Data",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, dataErr := range validateCredentialData(credentialSchema, credentialData) {
+		resp.Diagnostics.AddAttributeError(
+			dataPath,
+			"Invalid Credential Data",
+			fmt.Sprintf("Field %q %s for credential type %q.", dataErr.Field, dataErr.Message, config.Type.ValueString()),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *credentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, config credentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only values are only available in the configuration
+	data := plan.Data
+	if !config.DataWO.IsNull() {
+		data = config.DataWO
+	}
+
+	var credentialData map[string]interface{}
+	resp.Diagnostics.Append(data.Unmarshal(&credentialData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new credential
+	credential, err := r.client.createCredential(ctx, &n8n.Credential{
+		Name: plan.Name.ValueString(),
+		Type: plan.Type.ValueString(),
+		Data: &credentialData,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create n8n Credential",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringPointerValue(credential.Id)
+	plan.CreatedAt = convertTimeToTypesString(credential.CreatedAt)
+	plan.UpdatedAt = convertTimeToTypesString(credential.UpdatedAt)
+	plan.DataWO = jsontypes.NewNormalizedNull()
+
+	// Credentials are created in the personal project of the API key owner
+	if !plan.ProjectID.IsNull() {
+		if err := r.client.transferCredential(ctx, plan.ID.ValueString(), plan.ProjectID.ValueString()); err != nil {
+			// Keep the created credential in state, so it is not left behind
+			plan.ProjectID = types.StringNull()
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project_id"),
+				"Unable to Create n8n Credential",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the prior Terraform state, as the n8n API does not expose credentials.
+func (r *credentialResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update transfers the credential to another project, the only change that
+// does not replace the credential.
+func (r *credentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state credentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.ProjectID.IsNull() && !plan.ProjectID.Equal(state.ProjectID) {
+		if err := r.client.transferCredential(ctx, state.ID.ValueString(), plan.ProjectID.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project_id"),
+				"Unable to Transfer n8n Credential",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *credentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state credentialResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing credential
+	err := r.client.deleteCredential(ctx, state.ID.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete n8n Credential",
+			err.Error(),
+		)
+		return
+	}
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+)
+
+// secretFieldPattern matches credential field names that usually hold secrets.
+// The n8n schema endpoint does not flag password fields, so this is a best guess.
+var secretFieldPattern = regexp.MustCompile(`(?i)(password|secret|token|apikey|api_key|privatekey|private_key|passphrase|accesskey|signingkey)`)
+
+// credentialSchema is the JSON Schema n8n publishes for a credential type.
+type credentialSchema struct {
+	Type                 string                              `json:"type"`
+	Properties           map[string]credentialSchemaProperty `json:"properties"`
+	Required             []string                            `json:"required"`
+	AdditionalProperties interface{}                         `json:"additionalProperties"`
+
+	// Raw is the schema document as returned by n8n.
+	Raw json.RawMessage `json:"-"`
+}
+
+// credentialSchemaProperty describes a single field of a credential type.
+type credentialSchemaProperty struct {
+	Type string        `json:"type"`
+	Enum []interface{} `json:"enum"`
+}
+
+// credentialDataError describes a credential data field that does not
+// conform to the credential type schema.
+type credentialDataError struct {
+	Field   string
+	Message string
+}
+
+// validateCredentialData checks data against the credential type schema. Only
+// required fields, value types and unknown fields are checked; conditional
+// schema rules are left to n8n.
+func validateCredentialData(s *credentialSchema, data map[string]interface{}) []credentialDataError {
+	var errs []credentialDataError
+
+	for _, field := range s.Required {
+		if _, ok := data[field]; !ok {
+			errs = append(errs, credentialDataError{
+				Field:   field,
+				Message: "is required",
+			})
+		}
+	}
+
+	fields := make([]string, 0, len(data))
+	for field := range data {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		property, ok := s.Properties[field]
+		if !ok {
+			if additional, isBool := s.AdditionalProperties.(bool); isBool && !additional {
+				errs = append(errs, credentialDataError{
+					Field:   field,
+					Message: "is not a field of this credential type",
+				})
+			}
+			continue
+		}
+
+		if property.Type != "" && !matchesJSONType(property.Type, data[field]) {
+			errs = append(errs, credentialDataError{
+				Field:   field,
+				Message: fmt.Sprintf("must be of type %s, got %s", property.Type, jsonTypeOf(data[field])),
+			})
+			continue
+		}
+
+		if len(property.Enum) > 0 && !containsJSONScalar(property.Enum, data[field]) {
+			errs = append(errs, credentialDataError{
+				Field:   field,
+				Message: fmt.Sprintf("must be one of %v", property.Enum),
+			})
+		}
+	}
+
+	return errs
+}
+
+// matchesJSONType reports whether a decoded JSON value is of the JSON Schema type.
+func matchesJSONType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonTypeOf(value) == schemaType
+	}
+}
+
+// jsonTypeOf returns the JSON Schema type name of a decoded JSON value.
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// containsJSONScalar reports whether value is one of the scalar values in list.
+func containsJSONScalar(list []interface{}, value interface{}) bool {
+	switch value.(type) {
+	case bool, float64, string, nil:
+	default:
+		return false
+	}
+
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSecretCredentialField reports whether a credential field likely holds a secret.
+func isSecretCredentialField(field string) bool {
+	return secretFieldPattern.MatchString(field)
+}
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &credentialSchemaDataSource{}
+	_ datasource.DataSourceWithConfigure = &credentialSchemaDataSource{}
+)
+
+// NewCredentialSchemaDataSource is a helper function to simplify the provider implementation.
+func NewCredentialSchemaDataSource() datasource.DataSource {
+	return &credentialSchemaDataSource{}
+}
+
+// credentialSchemaDataSource is the data source implementation.
+type credentialSchemaDataSource struct {
+	client *client
+}
+
+// credentialSchemaDataSourceModel maps the data source schema data.
+type credentialSchemaDataSourceModel struct {
+	Type           types.String            `tfsdk:"type"`
+	Fields         []credentialSchemaField `tfsdk:"fields"`
+	RequiredFields []types.String          `tfsdk:"required_fields"`
+	SecretFields   []types.String          `tfsdk:"secret_fields"`
+	SchemaJSON     jsontypes.Normalized    `tfsdk:"schema_json"`
+}
+
+// credentialSchemaField represents a field of a credential type.
+type credentialSchemaField struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Required types.Bool   `tfsdk:"required"`
+	Secret   types.Bool   `tfsdk:"secret"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *credentialSchemaDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *credentialSchemaDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credential_schema"
+}
+
+// Schema defines the schema for the data source.
+func (d *credentialSchemaDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Describes the data fields of a credential type.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Description: "The credential type, e.g. `slackOAuth2Api` or `postgres`.",
+				Required:    true,
+			},
+			"fields": schema.ListNestedAttribute{
+				Description: "The fields of the credential type, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Field name",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "JSON type of the field, e.g. `string` or `number`.",
+							Computed:    true,
+						},
+						"required": schema.BoolAttribute{
+							Description: "Whether the field is required.",
+							Computed:    true,
+						},
+						"secret": schema.BoolAttribute{
+							Description: "Whether the field likely holds a secret. n8n does not flag secrets in the schema, so this is derived from the field name.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"required_fields": schema.ListAttribute{
+				Description: "The names of the required fields.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"secret_fields": schema.ListAttribute{
+				Description: "The names of the fields that likely hold secrets.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"schema_json": schema.StringAttribute{
+				Description: "The JSON Schema of the credential type as returned by n8n.",
+				CustomType:  jsontypes.NormalizedType{},
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *credentialSchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredClientError(&resp.Diagnostics)
+		return
+	}
+
+	var state credentialSchemaDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentialSchema, err := d.client.getCredentialSchema(ctx, state.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n Credential Schema",
+			err.Error(),
+		)
+		return
+	}
+
+	required := make(map[string]bool, len(credentialSchema.Required))
+	for _, field := range credentialSchema.Required {
+		required[field] = true
+	}
+
+	names := make([]string, 0, len(credentialSchema.Properties))
+	for name := range credentialSchema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	state.Fields = make([]credentialSchemaField, len(names))
+	state.RequiredFields = []types.String{}
+	state.SecretFields = []types.String{}
+	for i, name := range names {
+		secret := isSecretCredentialField(name)
+		state.Fields[i] = credentialSchemaField{
+			Name:     types.StringValue(name),
+			Type:     types.StringValue(credentialSchema.Properties[name].Type),
+			Required: types.BoolValue(required[name]),
+			Secret:   types.BoolValue(secret),
+		}
+		if required[name] {
+			state.RequiredFields = append(state.RequiredFields, types.StringValue(name))
+		}
+		if secret {
+			state.SecretFields = append(state.SecretFields, types.StringValue(name))
+		}
+	}
+	state.SchemaJSON = jsontypes.NewNormalizedValue(string(credentialSchema.Raw))
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidateCredentialData(t *testing.T) {
+	var s credentialSchema
+	err := json.Unmarshal([]byte(`{
+  "additionalProperties": false,
+  "type": "object",
+  "properties": {
+    "host": {"type": "string"},
+    "port": {"type": "number"},
+    "ssl": {"type": "string", "enum": ["disable", "allow", "require"]},
+    "allowUnauthorizedCerts": {"type": "boolean"}
+  },
+  "required": ["host", "port"]
+}`), &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		data     string
+		expected []credentialDataError
+	}{
+		"valid": {
+			data: `{"host": "db", "port": 5432, "ssl": "require"}`,
+		},
+		"missing required": {
+			data:     `{"host": "db"}`,
+			expected: []credentialDataError{{Field: "port", Message: "is required"}},
+		},
+		"wrong type": {
+			data:     `{"host": "db", "port": "5432", "allowUnauthorizedCerts": "yes"}`,
+			expected: []credentialDataError{{Field: "allowUnauthorizedCerts", Message: "must be of type boolean, got string"}, {Field: "port", Message: "must be of type number, got string"}},
+		},
+		"unknown field and enum": {
+			data:     `{"host": "db", "port": 5432, "ssl": "prefer", "password2": "x"}`,
+			expected: []credentialDataError{{Field: "password2", Message: "is not a field of this credential type"}, {Field: "ssl", Message: "must be one of [disable allow require]"}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var data map[string]interface{}
+			if err := json.Unmarshal([]byte(testCase.data), &data); err != nil {
+				t.Fatal(err)
+			}
+
+			got := validateCredentialData(&s, data)
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("validateCredentialData() = %v, want %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestIsSecretCredentialField(t *testing.T) {
+	for field, expected := range map[string]bool{
+		"apiKey":       true,
+		"password":     true,
+		"clientSecret": true,
+		"accessToken":  true,
+		"host":         false,
+		"clientId":     false,
+	} {
+		if got := isSecretCredentialField(field); got != expected {
+			t.Errorf("isSecretCredentialField(%q) = %t, want %t", field, got, expected)
+		}
+	}
+}
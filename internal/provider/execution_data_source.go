@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &executionDataSource{}
+	_ datasource.DataSourceWithConfigure = &executionDataSource{}
+)
+
+// NewExecutionDataSource is a helper function to simplify the provider implementation.
+func NewExecutionDataSource() datasource.DataSource {
+	return &executionDataSource{}
+}
+
+// executionDataSource is the data source implementation.
+type executionDataSource struct {
+	client *client
+}
+
+// executionDataSourceModel maps the data source schema data.
+type executionDataSourceModel struct {
+	execution
+	IncludeData types.Bool           `tfsdk:"include_data"`
+	Data        jsontypes.Normalized `tfsdk:"data"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *executionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *executionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_execution"
+}
+
+// Schema defines the schema for the data source.
+func (d *executionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := executionAttributes()
+	attributes["id"] = schema.StringAttribute{
+		Description: "Execution ID",
+		Required:    true,
+	}
+	attributes["workflow_id"] = schema.StringAttribute{
+		Description: "The ID of the executed workflow.",
+		Computed:    true,
+	}
+	attributes["include_data"] = schema.BoolAttribute{
+		Description: "Whether to fetch the data of the execution, such as the node outputs and errors. Defaults to `false`.",
+		Optional:    true,
+	}
+	attributes["data"] = schema.StringAttribute{
+		Description: "The data of the execution as JSON, if `include_data` is `true`.",
+		CustomType:  jsontypes.NormalizedType{},
+		Computed:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Reads an execution.",
+		Attributes:  attributes,
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *executionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredClientError(&resp.Diagnostics)
+		return
+	}
+
+	var state executionDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	e, err := d.client.getExecution(ctx, state.ID.ValueString(), state.IncludeData.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n Execution",
+			err.Error(),
+		)
+		return
+	}
+
+	state.execution = flattenExecution(e)
+	state.Data = jsontypes.NewNormalizedNull()
+	if state.IncludeData.ValueBool() && len(e.Data) > 0 && string(e.Data) != "null" {
+		state.Data = jsontypes.NewNormalizedValue(string(e.Data))
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultExecutionsLimit is the number of executions listed when limit is not
+// set. Execution history can be long, so listing all of it is opt-in.
+const defaultExecutionsLimit = 100
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &executionsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &executionsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &executionsDataSource{}
+)
+
+// NewExecutionsDataSource is a helper function to simplify the provider implementation.
+func NewExecutionsDataSource() datasource.DataSource {
+	return &executionsDataSource{}
+}
+
+// executionsDataSource is the data source implementation.
+type executionsDataSource struct {
+	client *client
+}
+
+// executionsDataSourceModel maps the data source schema data.
+type executionsDataSourceModel struct {
+	WorkflowID types.String `tfsdk:"workflow_id"`
+	Status     types.String `tfsdk:"status"`
+	ProjectID  types.String `tfsdk:"project_id"`
+	Limit      types.Int64  `tfsdk:"limit"`
+	Executions []execution  `tfsdk:"executions"`
+}
+
+// execution maps the execution schema data.
+type execution struct {
+	ID         types.String `tfsdk:"id"`
+	WorkflowID types.String `tfsdk:"workflow_id"`
+	Status     types.String `tfsdk:"status"`
+	Mode       types.String `tfsdk:"mode"`
+	Finished   types.Bool   `tfsdk:"finished"`
+	RetryOf    types.String `tfsdk:"retry_of"`
+	StartedAt  types.String `tfsdk:"started_at"`
+	StoppedAt  types.String `tfsdk:"stopped_at"`
+}
+
+// executionAttributes returns the schema attributes of an execution. The
+// workflow_id attribute is left to the caller, as it is an input of the
+// list data source.
+func executionAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"status": schema.StringAttribute{
+			Description: "The status of the execution, e.g. `success`, `error`, `waiting` or `running`.",
+			Computed:    true,
+		},
+		"mode": schema.StringAttribute{
+			Description: "How the execution was started, e.g. `manual`, `trigger` or `webhook`.",
+			Computed:    true,
+		},
+		"finished": schema.BoolAttribute{
+			Description: "Whether the execution has finished.",
+			Computed:    true,
+		},
+		"retry_of": schema.StringAttribute{
+			Description: "The ID of the execution this execution retries.",
+			Computed:    true,
+		},
+		"started_at": schema.StringAttribute{
+			Description: "The start date of the execution.",
+			Computed:    true,
+		},
+		"stopped_at": schema.StringAttribute{
+			Description: "The end date of the execution.",
+			Computed:    true,
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *executionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *executionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_executions"
+}
+
+// Schema defines the schema for the data source.
+func (d *executionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	executionAttrs := executionAttributes()
+	executionAttrs["id"] = schema.StringAttribute{
+		Description: "Execution ID",
+		Computed:    true,
+	}
+	executionAttrs["workflow_id"] = schema.StringAttribute{
+		Description: "The ID of the executed workflow.",
+		Computed:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Lists executions, most recent first.",
+		Attributes: map[string]schema.Attribute{
+			"workflow_id": schema.StringAttribute{
+				Description: "Only list executions of this workflow.",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Only list executions with this status, e.g. `success`, `error` or `waiting`.",
+				Optional:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "Only list executions of workflows in this project.",
+				Optional:    true,
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of executions to list, most recent first. Defaults to `%d`; `0` lists the whole execution history.", defaultExecutionsLimit),
+				Optional:    true,
+			},
+			"executions": schema.ListNestedAttribute{
+				Description: "The matching executions.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: executionAttrs,
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig rejects negative limits.
+func (d *executionsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var limit types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("limit"), &limit)...)
+	if resp.Diagnostics.HasError() || limit.IsNull() || limit.IsUnknown() {
+		return
+	}
+
+	if limit.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("limit"),
+			"Invalid Limit",
+			fmt.Sprintf("The limit must not be negative, got %d.", limit.ValueInt64()),
+		)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *executionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredClientError(&resp.Diagnostics)
+		return
+	}
+
+	var state executionsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultExecutionsLimit
+	if !state.Limit.IsNull() {
+		limit = int(state.Limit.ValueInt64())
+	}
+
+	executions, err := d.client.listExecutions(ctx, executionFilter{
+		WorkflowID: state.WorkflowID.ValueString(),
+		Status:     state.Status.ValueString(),
+		ProjectID:  state.ProjectID.ValueString(),
+		Limit:      limit,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n Executions",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Executions = make([]execution, len(executions))
+	for i, e := range executions {
+		state.Executions[i] = flattenExecution(&e)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// projectRoles are the roles a user can have in a project.
+var projectRoles = []string{"project:admin", "project:editor", "project:viewer"}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &projectMemberResource{}
+	_ resource.ResourceWithConfigure      = &projectMemberResource{}
+	_ resource.ResourceWithValidateConfig = &projectMemberResource{}
+	_ resource.ResourceWithImportState    = &projectMemberResource{}
+)
+
+// NewProjectMemberResource is a helper function to simplify the provider implementation.
+func NewProjectMemberResource() resource.Resource {
+	return &projectMemberResource{}
+}
+
+// projectMemberResource is the resource implementation.
+type projectMemberResource struct {
+	client *client
+}
+
+// projectMemberResourceModel maps the resource schema data.
+type projectMemberResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	ProjectID types.String `tfsdk:"project_id"`
+	UserID    types.String `tfsdk:"user_id"`
+	Role      types.String `tfsdk:"role"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *projectMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *projectMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_member"
+}
+
+// Schema defines the schema for the resource.
+func (r *projectMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the role of a user in a project. The n8n API does not list project members, so changes made outside Terraform are not detected.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Project and user ID in the form `<project_id>/<user_id>`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Description: "The ID of the project.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The ID of the user.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "The role of the user in the project, one of `project:admin`, `project:editor` or `project:viewer`.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures the role is a project role.
+func (r *projectMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var role types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("role"), &role)...)
+	if resp.Diagnostics.HasError() || role.IsNull() || role.IsUnknown() {
+		return
+	}
+
+	for _, projectRole := range projectRoles {
+		if role.ValueString() == projectRole {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(
+		path.Root("role"),
+		"Invalid Project Role",
+		fmt.Sprintf("The role must be one of %s, got %q.", strings.Join(projectRoles, ", "), role.ValueString()),
+	)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *projectMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan projectMemberResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Add user to project
+	err := r.client.addProjectUser(ctx, plan.ProjectID.ValueString(), plan.UserID.ValueString(), plan.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Add n8n Project Member",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(plan.ProjectID.ValueString() + "/" + plan.UserID.ValueString())
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the prior Terraform state, as the n8n API does not list project members.
+func (r *projectMemberResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *projectMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan projectMemberResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Change role of existing member
+	err := r.client.changeProjectUserRole(ctx, plan.ProjectID.ValueString(), plan.UserID.ValueString(), plan.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update n8n Project Member",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *projectMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state projectMemberResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove user from project
+	err := r.client.removeProjectUser(ctx, state.ProjectID.ValueString(), state.UserID.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Remove n8n Project Member",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports an existing membership by `<project_id>/<user_id>`.
+// The role is not readable and is set by the next apply.
+func (r *projectMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, userID, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || userID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <project_id>/<user_id>, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+}
@@ -2,14 +2,22 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
-	"github.com/edenreich/n8n-cli/n8n"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -37,8 +45,18 @@ type n8nProvider struct {
 
 // n8nProviderModel maps provider schema data to a Go type.
 type n8nProviderModel struct {
-	HostURL types.String `tfsdk:"host_url"`
-	APIKey  types.String `tfsdk:"api_key"`
+	HostURL               types.String  `tfsdk:"host_url"`
+	APIKey                types.String  `tfsdk:"api_key"`
+	CACertPEM             types.String  `tfsdk:"ca_cert_pem"`
+	CACertFile            types.String  `tfsdk:"ca_cert_file"`
+	ClientCert            types.String  `tfsdk:"client_cert"`
+	ClientKey             types.String  `tfsdk:"client_key"`
+	InsecureSkipVerify    types.Bool    `tfsdk:"insecure_skip_verify"`
+	ProxyURL              types.String  `tfsdk:"proxy_url"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	RetryMaxWait          types.Int64   `tfsdk:"retry_max_wait"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
 }
 
 // Metadata returns the provider type name.
@@ -52,19 +70,71 @@ func (p *n8nProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host_url": schema.StringAttribute{
-				MarkdownDescription: "URL of the n8n instance.",
-				Required:            true,
+				MarkdownDescription: "URL of the n8n instance. May also be provided via the `N8N_HOST_URL` environment variable.",
+				Optional:            true,
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "API Key for n8n instance.",
-				Required:            true,
+				MarkdownDescription: "API Key for n8n instance. May also be provided via the `N8N_API_KEY` environment variable.",
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates to trust in addition to the system CAs. Conflicts with `ca_cert_file`.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file with PEM encoded CA certificates to trust in addition to the system CAs. Conflicts with `ca_cert_pem`.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Requires `client_key`.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of `client_cert`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip the verification of the server certificate. Only use this for testing.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP, HTTPS or SOCKS5 proxy to connect through. Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of times a request is retried after a `429` or `5xx` response, with exponential backoff honoring the `Retry-After` header. "+
+					"When the server asks to wait longer than `retry_max_wait`, the request fails right away instead of being retried early. "+
+					"Server errors of `POST` and `PATCH` requests and of webhook calls are not retried, as the request may already have been processed. Defaults to `%d`, `0` disables retries.", defaultMaxRetries),
+				Optional: true,
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of seconds to wait between two attempts, including waits requested by `Retry-After`. Defaults to `%d`.", int(defaultRetryMaxWait.Seconds())),
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of requests per second sent to the n8n instance by all resources and data sources, allowing bursts of up to one second worth of requests. " +
+					"Unlimited by default.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests in flight to the n8n instance at the same time, independent of the Terraform parallelism. Unlimited by default.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
-// Configure prepares a HashiCups API client for data sources and resources.
+// hasUnknownValues reports whether any setting is only known after apply.
+func (m n8nProviderModel) hasUnknownValues() bool {
+	return m.HostURL.IsUnknown() || m.APIKey.IsUnknown() || m.CACertPEM.IsUnknown() ||
+		m.CACertFile.IsUnknown() || m.ClientCert.IsUnknown() || m.ClientKey.IsUnknown() ||
+		m.InsecureSkipVerify.IsUnknown() || m.ProxyURL.IsUnknown() || m.MaxRetries.IsUnknown() ||
+		m.RetryMaxWait.IsUnknown() || m.RequestsPerSecond.IsUnknown() || m.MaxConcurrentRequests.IsUnknown()
+}
+
+// Configure prepares an n8n API client for data sources and resources.
 func (p *n8nProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var config n8nProviderModel
 	diags := req.Config.Get(ctx, &config)
@@ -73,32 +143,237 @@ func (p *n8nProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	// Create a new n8n client
-	n8nClient, err := n8n.NewClient(config.HostURL.ValueString(), config.APIKey.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create n8n API Client",
-			fmt.Sprintf("Unable to create n8n API client: %s", err),
+	// The client cannot be created from values only known after apply. The
+	// plan goes on without it, and Terraform configures the provider again
+	// with the known values before applying.
+	if config.hasUnknownValues() {
+		tflog.Debug(ctx, "Skipping n8n client creation, the provider configuration contains unknown values")
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+		}
+		return
+	}
+
+	// Configuration values take precedence over environment variables
+	hostURL := os.Getenv("N8N_HOST_URL")
+	apiKey := os.Getenv("N8N_API_KEY")
+	if !config.HostURL.IsNull() {
+		hostURL = config.HostURL.ValueString()
+	}
+	if !config.APIKey.IsNull() {
+		apiKey = config.APIKey.ValueString()
+	}
+
+	if hostURL == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host_url"),
+			"Missing n8n Host URL",
+			"The provider cannot create the n8n API client as there is a missing or empty value for the n8n host URL. "+
+				"Set the host_url value in the configuration or use the N8N_HOST_URL environment variable.",
+		)
+	} else if u, err := url.Parse(hostURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host_url"),
+			"Invalid n8n Host URL",
+			fmt.Sprintf("The n8n host URL must be an absolute http or https URL such as https://n8n.example.com, got %q.", hostURL),
+		)
+	}
+	if apiKey == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key"),
+			"Missing n8n API Key",
+			"The provider cannot create the n8n API client as there is a missing or empty value for the n8n API key. "+
+				"Set the api_key value in the configuration or use the N8N_API_KEY environment variable.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpClient := newHTTPClient(config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxRetries := int64(defaultMaxRetries)
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+	if maxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Max Retries",
+			"max_retries must not be negative.",
 		)
+	}
+
+	retryMaxWait := defaultRetryMaxWait
+	if !config.RetryMaxWait.IsNull() {
+		retryMaxWait = time.Duration(config.RetryMaxWait.ValueInt64()) * time.Second
+	}
+	if retryMaxWait < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_wait"),
+			"Invalid Retry Max Wait",
+			"retry_max_wait must not be negative.",
+		)
+	}
+
+	if config.RequestsPerSecond.ValueFloat64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid Requests Per Second",
+			"requests_per_second must not be negative.",
+		)
+	}
+	if config.MaxConcurrentRequests.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Max Concurrent Requests",
+			"max_concurrent_requests must not be negative.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Create a new n8n client
 	p.client = &client{
-		N8NClient: n8nClient,
+		BaseURL:      strings.TrimSuffix(hostURL, "/"),
+		APIKey:       apiKey,
+		HTTPClient:   httpClient,
+		MaxRetries:   int(maxRetries),
+		RetryMaxWait: retryMaxWait,
+		limiter:      newRateLimiter(config.RequestsPerSecond.ValueFloat64(), int(config.MaxConcurrentRequests.ValueInt64())),
 	}
 
 	resp.DataSourceData = p.client
 	resp.ResourceData = p.client
 }
 
+// newHTTPClient returns an HTTP client with the TLS and proxy settings of the
+// provider configuration.
+func newHTTPClient(config n8nProviderModel, diags *diag.Diagnostics) *http.Client {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+
+	// CA certificates
+	var caCertPEM []byte
+	caCertPath := path.Root("ca_cert_pem")
+	switch {
+	case !config.CACertPEM.IsNull() && !config.CACertFile.IsNull():
+		diags.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Conflicting CA Certificates",
+			"Only one of ca_cert_pem and ca_cert_file can be configured.",
+		)
+	case !config.CACertPEM.IsNull():
+		caCertPEM = []byte(config.CACertPEM.ValueString())
+	case !config.CACertFile.IsNull():
+		caCertPath = path.Root("ca_cert_file")
+		var err error
+		caCertPEM, err = os.ReadFile(config.CACertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				caCertPath,
+				"Unable to Read CA Certificates",
+				err.Error(),
+			)
+		}
+	}
+	if caCertPEM != nil {
+		pool, err := certPoolFromPEM(caCertPEM)
+		if err != nil {
+			diags.AddAttributeError(
+				caCertPath,
+				"Invalid CA Certificates",
+				err.Error(),
+			)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	// Client certificate
+	switch {
+	case config.ClientCert.IsNull() != config.ClientKey.IsNull():
+		diags.AddAttributeError(
+			path.Root("client_key"),
+			"Incomplete Client Certificate",
+			"client_cert and client_key must be configured together.",
+		)
+	case !config.ClientCert.IsNull():
+		certificate, err := tls.X509KeyPair([]byte(config.ClientCert.ValueString()), []byte(config.ClientKey.ValueString()))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("client_cert"),
+				"Invalid Client Certificate",
+				err.Error(),
+			)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	// Proxy
+	var proxyURL *url.URL
+	if !config.ProxyURL.IsNull() {
+		var err error
+		proxyURL, err = parseProxyURL(config.ProxyURL.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				err.Error(),
+			)
+		}
+	}
+
+	if diags.HasError() {
+		return nil
+	}
+
+	return &http.Client{Transport: newTransport(tlsConfig, proxyURL)}
+}
+
+// addUnconfiguredClientError reports that a data source cannot be read, as
+// the provider configuration contains values that are only known after apply.
+func addUnconfiguredClientError(diags *diag.Diagnostics) {
+	diags.AddError(
+		"Unconfigured n8n Client",
+		"The provider configuration contains values that are only known after apply, so the data source cannot be read yet. "+
+			"Either target apply the source of the values first or set the values statically in the configuration.",
+	)
+}
+
 // DataSources defines the data sources implemented in the provider.
 func (p *n8nProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewWorkflowDataSource,
+		NewCredentialSchemaDataSource,
+		NewTagsDataSource,
+		NewWorkflowsDataSource,
+		NewVariablesDataSource,
+		NewUsersDataSource,
+		NewExecutionsDataSource,
+		NewExecutionDataSource,
+		NewAuditDataSource,
 	}
 }
 
 // Resources defines the resources implemented in the provider.
 func (p *n8nProvider) Resources(_ context.Context) []func() resource.Resource {
-	return nil
+	return []func() resource.Resource{
+		NewWorkflowResource,
+		NewCredentialResource,
+		NewTagResource,
+		NewWorkflowTagsResource,
+		NewWorkflowActivationResource,
+		NewVariableResource,
+		NewProjectResource,
+		NewProjectMemberResource,
+		NewUserResource,
+		NewSourceControlPullResource,
+		NewWorkflowRunResource,
+	}
 }
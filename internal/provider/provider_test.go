@@ -4,10 +4,14 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 )
 
@@ -32,3 +36,196 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestProviderSchemas(t *testing.T) {
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range resp.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+}
+
+func TestProviderConfigure(t *testing.T) {
+	testCases := map[string]struct {
+		hostURL         tftypes.Value
+		apiKey          tftypes.Value
+		attributes      map[string]tftypes.Value
+		env             map[string]string
+		deferralAllowed bool
+		expectedURL     string
+		deferred        bool
+		errored         bool
+	}{
+		"configured": {
+			hostURL:     tftypes.NewValue(tftypes.String, "https://n8n.example.com/"),
+			apiKey:      tftypes.NewValue(tftypes.String, "secret"),
+			env:         map[string]string{"N8N_HOST_URL": "https://other.example.com"},
+			expectedURL: "https://n8n.example.com",
+		},
+		"environment": {
+			hostURL:     tftypes.NewValue(tftypes.String, nil),
+			apiKey:      tftypes.NewValue(tftypes.String, nil),
+			env:         map[string]string{"N8N_HOST_URL": "http://localhost:5678", "N8N_API_KEY": "secret"},
+			expectedURL: "http://localhost:5678",
+		},
+		"missing": {
+			hostURL: tftypes.NewValue(tftypes.String, nil),
+			apiKey:  tftypes.NewValue(tftypes.String, nil),
+			errored: true,
+		},
+		"invalid url": {
+			hostURL: tftypes.NewValue(tftypes.String, "n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			errored: true,
+		},
+		"unknown": {
+			hostURL: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			env:     map[string]string{"N8N_HOST_URL": "http://localhost:5678"},
+		},
+		"unknown with deferral": {
+			hostURL: tftypes.NewValue(tftypes.String, "https://n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			attributes: map[string]tftypes.Value{
+				"proxy_url": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+			deferralAllowed: true,
+			deferred:        true,
+		},
+		"proxy": {
+			hostURL: tftypes.NewValue(tftypes.String, "https://n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			attributes: map[string]tftypes.Value{
+				"proxy_url":            tftypes.NewValue(tftypes.String, "http://proxy.example.com:3128"),
+				"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expectedURL: "https://n8n.example.com",
+		},
+		"invalid proxy": {
+			hostURL: tftypes.NewValue(tftypes.String, "https://n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			attributes: map[string]tftypes.Value{
+				"proxy_url": tftypes.NewValue(tftypes.String, "ftp://proxy.example.com"),
+			},
+			errored: true,
+		},
+		"conflicting ca certificates": {
+			hostURL: tftypes.NewValue(tftypes.String, "https://n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			attributes: map[string]tftypes.Value{
+				"ca_cert_pem":  tftypes.NewValue(tftypes.String, "-----BEGIN CERTIFICATE-----"),
+				"ca_cert_file": tftypes.NewValue(tftypes.String, "ca.pem"),
+			},
+			errored: true,
+		},
+		"invalid ca certificates": {
+			hostURL: tftypes.NewValue(tftypes.String, "https://n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			attributes: map[string]tftypes.Value{
+				"ca_cert_pem": tftypes.NewValue(tftypes.String, "not a certificate"),
+			},
+			errored: true,
+		},
+		"retries": {
+			hostURL: tftypes.NewValue(tftypes.String, "https://n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			attributes: map[string]tftypes.Value{
+				"max_retries":    tftypes.NewValue(tftypes.Number, 5),
+				"retry_max_wait": tftypes.NewValue(tftypes.Number, 60),
+			},
+			expectedURL: "https://n8n.example.com",
+		},
+		"negative retries": {
+			hostURL: tftypes.NewValue(tftypes.String, "https://n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			attributes: map[string]tftypes.Value{
+				"max_retries": tftypes.NewValue(tftypes.Number, -1),
+			},
+			errored: true,
+		},
+		"rate limit": {
+			hostURL: tftypes.NewValue(tftypes.String, "https://n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			attributes: map[string]tftypes.Value{
+				"requests_per_second":     tftypes.NewValue(tftypes.Number, 2.5),
+				"max_concurrent_requests": tftypes.NewValue(tftypes.Number, 4),
+			},
+			expectedURL: "https://n8n.example.com",
+		},
+		"negative rate limit": {
+			hostURL: tftypes.NewValue(tftypes.String, "https://n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			attributes: map[string]tftypes.Value{
+				"requests_per_second": tftypes.NewValue(tftypes.Number, -1),
+			},
+			errored: true,
+		},
+		"client certificate without key": {
+			hostURL: tftypes.NewValue(tftypes.String, "https://n8n.example.com"),
+			apiKey:  tftypes.NewValue(tftypes.String, "secret"),
+			attributes: map[string]tftypes.Value{
+				"client_cert": tftypes.NewValue(tftypes.String, "-----BEGIN CERTIFICATE-----"),
+			},
+			errored: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("N8N_HOST_URL", testCase.env["N8N_HOST_URL"])
+			t.Setenv("N8N_API_KEY", testCase.env["N8N_API_KEY"])
+
+			p := &n8nProvider{}
+			var schemaResp provider.SchemaResponse
+			p.Schema(context.Background(), provider.SchemaRequest{}, &schemaResp)
+
+			objectType, ok := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+			if !ok {
+				t.Fatal("provider schema is not an object")
+			}
+			values := map[string]tftypes.Value{}
+			for name, attributeType := range objectType.AttributeTypes {
+				values[name] = tftypes.NewValue(attributeType, nil)
+			}
+			values["host_url"] = testCase.hostURL
+			values["api_key"] = testCase.apiKey
+			for name, value := range testCase.attributes {
+				values[name] = value
+			}
+
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(objectType, values),
+			}
+
+			var resp provider.ConfigureResponse
+			p.Configure(context.Background(), provider.ConfigureRequest{
+				Config:             config,
+				ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: testCase.deferralAllowed},
+			}, &resp)
+
+			if resp.Diagnostics.HasError() != testCase.errored {
+				t.Fatalf("diagnostics = %v, want error %t", resp.Diagnostics, testCase.errored)
+			}
+			if (resp.Deferred != nil) != testCase.deferred {
+				t.Errorf("deferred = %v, want %t", resp.Deferred, testCase.deferred)
+			}
+			if testCase.expectedURL == "" {
+				// No client is created for errors and unknown values
+				if p.client != nil {
+					t.Errorf("client = %+v, want nil", p.client)
+				}
+			} else if p.client == nil || p.client.BaseURL != testCase.expectedURL {
+				t.Errorf("client = %+v, want BaseURL %q", p.client, testCase.expectedURL)
+			}
+		})
+	}
+}
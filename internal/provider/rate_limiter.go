@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter limits the requests of all resources and data sources sharing a
+// client. A token bucket limits the request rate and a semaphore the number of
+// requests in flight. A nil rateLimiter does not limit anything.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	slots chan struct{}
+}
+
+// newRateLimiter returns a limiter for the given number of requests per second
+// and concurrent requests, where 0 means unlimited. It returns nil when
+// neither is limited.
+func newRateLimiter(requestsPerSecond float64, maxConcurrentRequests int) *rateLimiter {
+	if requestsPerSecond <= 0 && maxConcurrentRequests <= 0 {
+		return nil
+	}
+
+	l := &rateLimiter{}
+	if requestsPerSecond > 0 {
+		// Allow a burst of one second worth of requests
+		l.rate = requestsPerSecond
+		l.burst = math.Max(1, math.Floor(requestsPerSecond))
+		l.tokens = l.burst
+		l.last = time.Now()
+	}
+	if maxConcurrentRequests > 0 {
+		l.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	return l
+}
+
+// acquire blocks until a request may be sent. The returned function must be
+// called once the request has finished.
+func (l *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		release = func() { <-l.slots }
+	}
+
+	if wait := l.reserve(time.Now()); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
+
+// reserve takes a token from the bucket and returns how long to wait until
+// the token is available.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.After(l.last) {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+	}
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
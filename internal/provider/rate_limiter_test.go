@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	now := time.Now()
+	l := newRateLimiter(2, 0)
+	l.last = now
+
+	// The burst is available immediately
+	for i := 0; i < 2; i++ {
+		if wait := l.reserve(now); wait != 0 {
+			t.Errorf("reserve %d = %s, want 0", i, wait)
+		}
+	}
+
+	if wait := l.reserve(now); wait != 500*time.Millisecond {
+		t.Errorf("reserve = %s, want 500ms", wait)
+	}
+	if wait := l.reserve(now); wait != time.Second {
+		t.Errorf("reserve = %s, want 1s", wait)
+	}
+
+	// The bucket refills over time, up to the burst
+	if wait := l.reserve(now.Add(time.Minute)); wait != 0 {
+		t.Errorf("reserve after refill = %s, want 0", wait)
+	}
+	if l.tokens != 1 {
+		t.Errorf("tokens = %v, want 1", l.tokens)
+	}
+}
+
+func TestNewRateLimiterUnlimited(t *testing.T) {
+	l := newRateLimiter(0, 0)
+	if l != nil {
+		t.Fatalf("newRateLimiter = %+v, want nil", l)
+	}
+
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	release()
+}
+
+func TestClientMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"id":"1","name":"dev"}`))
+	}))
+	defer server.Close()
+
+	c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client(), limiter: newRateLimiter(0, 2)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.getTag(context.Background(), "1"); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("max requests in flight = %d, want 2", maxInFlight)
+	}
+}
+
+func TestRateLimiterAcquireCanceled(t *testing.T) {
+	l := newRateLimiter(0, 1)
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.acquire(ctx); err == nil {
+		t.Error("expected error for canceled context")
+	}
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultMaxRetries is the number of retries when max_retries is not set.
+	defaultMaxRetries = 3
+
+	// defaultRetryMaxWait is the longest wait between two attempts when
+	// retry_max_wait is not set.
+	defaultRetryMaxWait = 30 * time.Second
+)
+
+// retryBaseWait is the wait before the first retry. It doubles with every
+// further retry.
+var retryBaseWait = time.Second
+
+// send sends the request built by newRequest and returns the status code and
+// body of the response. Every attempt waits for the rate limiter of the
+// client. Responses for which retryable returns true, such as those rejected
+// by isRetryable, are retried with exponential backoff.
+func (c *client) send(ctx context.Context, retryable func(method string, statusCode int) bool, newRequest func() (*http.Request, error)) (int, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		release, err := c.limiter.acquire(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			release()
+			return 0, nil, err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		release()
+		if err != nil {
+			return 0, nil, fmt.Errorf("error reading response body: %w", err)
+		}
+
+		if attempt >= c.MaxRetries || !retryable(req.Method, resp.StatusCode) {
+			return resp.StatusCode, body, nil
+		}
+
+		wait, ok := c.retryWait(attempt, resp.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			tflog.Debug(ctx, "Not retrying n8n request, Retry-After exceeds the maximum wait", map[string]interface{}{
+				"method":      req.Method,
+				"url":         req.URL.Redacted(),
+				"status_code": resp.StatusCode,
+				"retry_after": resp.Header.Get("Retry-After"),
+			})
+			return resp.StatusCode, body, nil
+		}
+
+		tflog.Debug(ctx, "Retrying n8n request", map[string]interface{}{
+			"method":      req.Method,
+			"url":         req.URL.Redacted(),
+			"status_code": resp.StatusCode,
+			"attempt":     attempt + 1,
+			"wait":        wait.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return 0, nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// isRetryable reports whether a request can safely be sent again after the
+// given response status. A 429 means the request was rejected before it was
+// processed, so it is retried for every method. Server errors are only
+// retried for idempotent methods, as the request may already have been
+// processed and a repeated POST would, for example, create a duplicate.
+func isRetryable(method string, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if statusCode < 500 || statusCode == http.StatusNotImplemented {
+		return false
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRateLimited reports whether the request was rejected by a rate limit. It
+// is the only status on which a webhook call is retried: a webhook runs a
+// workflow with side effects, and after a server error the workflow may
+// already have run, whatever the method.
+func isRateLimited(_ string, statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests
+}
+
+// retryWait returns the time to wait before the next attempt. The Retry-After
+// header takes precedence over the exponential backoff, which is capped at
+// RetryMaxWait. A Retry-After longer than RetryMaxWait is not cut short, as an
+// early retry would only be rejected again; false is returned instead to give
+// up.
+func (c *client) retryWait(attempt int, retryAfter string, now time.Time) (time.Duration, bool) {
+	if wait, ok := parseRetryAfter(retryAfter, now); ok {
+		return wait, wait <= c.RetryMaxWait
+	}
+
+	wait := retryBaseWait
+	for i := 0; i < attempt && wait < c.RetryMaxWait; i++ {
+		wait *= 2
+	}
+	if wait > c.RetryMaxWait {
+		wait = c.RetryMaxWait
+	}
+
+	return wait, true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientDoRequestRetries(t *testing.T) {
+	retryBaseWait = time.Millisecond
+	defer func() { retryBaseWait = time.Second }()
+
+	testCases := map[string]struct {
+		method        string
+		statusCodes   []int
+		retryAfter    string
+		expectedCalls int
+		errored       bool
+	}{
+		"get after server errors": {
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			expectedCalls: 3,
+		},
+		"post after rate limit": {
+			method:        http.MethodPost,
+			statusCodes:   []int{http.StatusTooManyRequests, http.StatusOK},
+			expectedCalls: 2,
+		},
+		"post after server error": {
+			method:        http.MethodPost,
+			statusCodes:   []int{http.StatusBadGateway, http.StatusOK},
+			expectedCalls: 1,
+			errored:       true,
+		},
+		"client error": {
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusBadRequest, http.StatusOK},
+			expectedCalls: 1,
+			errored:       true,
+		},
+		"retries exhausted": {
+			method:        http.MethodDelete,
+			statusCodes:   []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests},
+			expectedCalls: 3,
+			errored:       true,
+		},
+		"retry after exceeds maximum wait": {
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:    "120",
+			expectedCalls: 1,
+			errored:       true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != testCase.method {
+					t.Errorf("method = %s, want %s", r.Method, testCase.method)
+				}
+				if r.Method == http.MethodPost && r.ContentLength == 0 {
+					t.Error("request body is empty")
+				}
+
+				statusCode := testCase.statusCodes[calls]
+				calls++
+				if statusCode == http.StatusTooManyRequests {
+					retryAfter := testCase.retryAfter
+					if retryAfter == "" {
+						retryAfter = "0"
+					}
+					w.Header().Set("Retry-After", retryAfter)
+				}
+				w.WriteHeader(statusCode)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client(), MaxRetries: 2, RetryMaxWait: time.Second}
+
+			var body interface{}
+			if testCase.method == http.MethodPost {
+				body = map[string]string{"name": "example"}
+			}
+
+			err := c.doRequest(context.Background(), testCase.method, "/tags", body, nil)
+			if (err != nil) != testCase.errored {
+				t.Errorf("error = %v, want error %t", err, testCase.errored)
+			}
+			if calls != testCase.expectedCalls {
+				t.Errorf("calls = %d, want %d", calls, testCase.expectedCalls)
+			}
+		})
+	}
+}
+
+func TestClientTriggerWebhookRetries(t *testing.T) {
+	retryBaseWait = time.Millisecond
+	defer func() { retryBaseWait = time.Second }()
+
+	testCases := map[string]struct {
+		method        string
+		statusCodes   []int
+		expectedCalls int
+		errored       bool
+	}{
+		"get after server error": {
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusInternalServerError, http.StatusOK},
+			expectedCalls: 1,
+			errored:       true,
+		},
+		"put after server error": {
+			method:        http.MethodPut,
+			statusCodes:   []int{http.StatusBadGateway, http.StatusOK},
+			expectedCalls: 1,
+			errored:       true,
+		},
+		"post after rate limit": {
+			method:        http.MethodPost,
+			statusCodes:   []int{http.StatusTooManyRequests, http.StatusOK},
+			expectedCalls: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/webhook/smoke-test" {
+					t.Errorf("path = %s, want /webhook/smoke-test", r.URL.Path)
+				}
+
+				statusCode := testCase.statusCodes[calls]
+				calls++
+				if statusCode == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "0")
+				}
+				w.WriteHeader(statusCode)
+			}))
+			defer server.Close()
+
+			c := &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client(), MaxRetries: 2, RetryMaxWait: time.Second}
+
+			err := c.triggerWebhook(context.Background(), testCase.method, "smoke-test", nil, nil)
+			if (err != nil) != testCase.errored {
+				t.Errorf("error = %v, want error %t", err, testCase.errored)
+			}
+			if calls != testCase.expectedCalls {
+				t.Errorf("calls = %d, want %d", calls, testCase.expectedCalls)
+			}
+		})
+	}
+}
+
+func TestClientRetryWait(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := &client{RetryMaxWait: 10 * time.Second}
+
+	testCases := map[string]struct {
+		attempt    int
+		retryAfter string
+		expected   time.Duration
+		giveUp     bool
+	}{
+		"first attempt": {
+			attempt:  0,
+			expected: time.Second,
+		},
+		"backoff": {
+			attempt:  2,
+			expected: 4 * time.Second,
+		},
+		"backoff capped": {
+			attempt:  40,
+			expected: 10 * time.Second,
+		},
+		"retry after seconds": {
+			attempt:    3,
+			retryAfter: "2",
+			expected:   2 * time.Second,
+		},
+		"retry after date": {
+			attempt:    0,
+			retryAfter: "Thu, 02 Jan 2025 03:04:08 GMT",
+			expected:   3 * time.Second,
+		},
+		"retry after too long": {
+			attempt:    0,
+			retryAfter: "120",
+			expected:   120 * time.Second,
+			giveUp:     true,
+		},
+		"invalid retry after": {
+			attempt:    1,
+			retryAfter: "soon",
+			expected:   2 * time.Second,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := c.retryWait(testCase.attempt, testCase.retryAfter, now)
+			if got != testCase.expected || ok == testCase.giveUp {
+				t.Errorf("retryWait = %s, %t, want %s, %t", got, ok, testCase.expected, !testCase.giveUp)
+			}
+		})
+	}
+}
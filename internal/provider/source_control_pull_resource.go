@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edenreich/n8n-cli/n8n"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &sourceControlPullResource{}
+	_ resource.ResourceWithConfigure = &sourceControlPullResource{}
+)
+
+// NewSourceControlPullResource is a helper function to simplify the provider implementation.
+func NewSourceControlPullResource() resource.Resource {
+	return &sourceControlPullResource{}
+}
+
+// sourceControlPullResource is the resource implementation.
+type sourceControlPullResource struct {
+	client *client
+}
+
+// sourceControlPullResourceModel maps the resource schema data.
+type sourceControlPullResourceModel struct {
+	ID               types.String                    `tfsdk:"id"`
+	Force            types.Bool                      `tfsdk:"force"`
+	Variables        types.Map                       `tfsdk:"variables"`
+	Trigger          types.String                    `tfsdk:"trigger"`
+	Workflows        []sourceControlPulledWorkflow   `tfsdk:"workflows"`
+	Credentials      []sourceControlPulledCredential `tfsdk:"credentials"`
+	Tags             []sourceControlPulledTag        `tfsdk:"tags"`
+	VariablesAdded   []types.String                  `tfsdk:"variables_added"`
+	VariablesChanged []types.String                  `tfsdk:"variables_changed"`
+}
+
+// sourceControlPulledWorkflow is a workflow imported by a pull.
+type sourceControlPulledWorkflow struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+// sourceControlPulledCredential is a credential imported by a pull.
+type sourceControlPulledCredential struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+// sourceControlPulledTag is a tag imported by a pull.
+type sourceControlPulledTag struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *sourceControlPullResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *sourceControlPullResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_control_pull"
+}
+
+// Schema defines the schema for the resource.
+func (r *sourceControlPullResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Pulls workflows, credentials, tags and variables from the Git branch connected to the instance. " +
+			"The pull runs when the resource is created and again whenever `trigger` changes. Destroying the resource does not undo the pull.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The time of the last pull.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"force": schema.BoolAttribute{
+				Description: "Whether to overwrite local changes that conflict with the branch.",
+				Optional:    true,
+			},
+			"variables": schema.MapAttribute{
+				Description: "Values for variables that are pulled, by key.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"trigger": schema.StringAttribute{
+				Description: "An arbitrary value, such as the commit hash to deploy. Changing it pulls again.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"workflows": schema.ListNestedAttribute{
+				Description: "The workflows imported by the last pull.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Workflow ID",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the workflow.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"credentials": schema.ListNestedAttribute{
+				Description: "The credentials imported by the last pull.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Credential ID",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the credential.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The credential type.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"tags": schema.ListNestedAttribute{
+				Description: "The tags imported by the last pull.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Tag ID",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Tag name",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"variables_added": schema.ListAttribute{
+				Description: "The keys of the variables added by the last pull.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"variables_changed": schema.ListAttribute{
+				Description: "The keys of the variables changed by the last pull.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create pulls from source control and sets the initial Terraform state.
+func (r *sourceControlPullResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// The computed lists are unknown in the plan, so only the inputs are read
+	var state sourceControlPullResourceModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("force"), &state.Force)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("variables"), &state.Variables)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("trigger"), &state.Trigger)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pull := n8n.Pull{
+		Force: state.Force.ValueBoolPointer(),
+	}
+	if !state.Variables.IsNull() {
+		var values map[string]string
+		resp.Diagnostics.Append(state.Variables.ElementsAs(ctx, &values, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		variables := make(map[string]interface{}, len(values))
+		for key, value := range values {
+			variables[key] = value
+		}
+		pull.Variables = &variables
+	}
+
+	result, err := r.client.pullSourceControl(ctx, pull)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Pull n8n Source Control",
+			err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	flattenImportResult(result, &state)
+
+	// Set state
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the prior Terraform state, as a pull is an operation and not an object.
+func (r *sourceControlPullResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update records changes to force and variables, which take effect on the next pull.
+func (r *sourceControlPullResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state sourceControlPullResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("force"), &state.Force)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("variables"), &state.Variables)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the Terraform state; the pulled changes are kept.
+func (r *sourceControlPullResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &tagsDataSource{}
+	_ datasource.DataSourceWithConfigure = &tagsDataSource{}
+)
+
+// NewTagsDataSource is a helper function to simplify the provider implementation.
+func NewTagsDataSource() datasource.DataSource {
+	return &tagsDataSource{}
+}
+
+// tagsDataSource is the data source implementation.
+type tagsDataSource struct {
+	client *client
+}
+
+// tagsDataSourceModel maps the data source schema data.
+type tagsDataSourceModel struct {
+	Tags []tag `tfsdk:"tags"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *tagsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *tagsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tags"
+}
+
+// Schema defines the schema for the data source.
+func (d *tagsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all tags.",
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.ListNestedAttribute{
+				Description: "The tags of the n8n instance.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Tag ID",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Tag name",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Tag creation date",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "Tag last update date",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *tagsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredClientError(&resp.Diagnostics)
+		return
+	}
+
+	tags, err := d.client.listTags(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n Tags",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	state := tagsDataSourceModel{
+		Tags: flattenTags(tags),
+	}
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// newTransport returns an HTTP transport with the TLS and proxy settings of
+// the provider. Without a proxy URL, the proxy environment variables apply.
+func newTransport(tlsConfig *tls.Config, proxyURL *url.URL) *http.Transport {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		transport = &http.Transport{Proxy: http.ProxyFromEnvironment}
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = tlsConfig
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport
+}
+
+// certPoolFromPEM returns the system certificate pool extended by the PEM
+// encoded CA certificates.
+func certPoolFromPEM(caCertPEM []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(caCertPEM) {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	return pool, nil
+}
+
+// parseProxyURL parses the URL of an HTTP(S) or SOCKS5 proxy.
+func parseProxyURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, errors.New("the scheme must be http, https or socks5")
+	}
+	if u.Host == "" {
+		return nil, errors.New("the URL has no host")
+	}
+
+	return u, nil
+}
@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// globalRoles are the roles a user can have on the instance. The owner role
+// cannot be assigned through the API.
+var globalRoles = []string{"global:admin", "global:member"}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &userResource{}
+	_ resource.ResourceWithConfigure      = &userResource{}
+	_ resource.ResourceWithValidateConfig = &userResource{}
+	_ resource.ResourceWithImportState    = &userResource{}
+)
+
+// NewUserResource is a helper function to simplify the provider implementation.
+func NewUserResource() resource.Resource {
+	return &userResource{}
+}
+
+// userResource is the resource implementation.
+type userResource struct {
+	client *client
+}
+
+// user maps the user schema data.
+type user struct {
+	ID        types.String `tfsdk:"id"`
+	Email     types.String `tfsdk:"email"`
+	FirstName types.String `tfsdk:"first_name"`
+	LastName  types.String `tfsdk:"last_name"`
+	Role      types.String `tfsdk:"role"`
+	IsPending types.Bool   `tfsdk:"is_pending"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+// userResourceModel maps the resource schema data.
+type userResourceModel struct {
+	user
+	InviteAcceptURL  types.String `tfsdk:"invite_accept_url"`
+	TransferToUserID types.String `tfsdk:"transfer_to_user_id"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *userResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// Schema defines the schema for the resource.
+func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a user. Creating the resource invites the user by email.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "User ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Description: "The email address the invitation is sent to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"first_name": schema.StringAttribute{
+				Description: "The first name, set by the user when accepting the invitation.",
+				Computed:    true,
+			},
+			"last_name": schema.StringAttribute{
+				Description: "The last name, set by the user when accepting the invitation.",
+				Computed:    true,
+			},
+			"role": schema.StringAttribute{
+				Description: "The global role of the user, `global:admin` or `global:member`. Defaults to `global:member`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("global:member"),
+			},
+			"is_pending": schema.BoolAttribute{
+				Description: "Whether the user has not accepted the invitation yet.",
+				Computed:    true,
+			},
+			"invite_accept_url": schema.StringAttribute{
+				Description: "The URL to accept the invitation, for sharing it when n8n does not send emails. Only known after the user is created.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"transfer_to_user_id": schema.StringAttribute{
+				Description: "The ID of a user to transfer the workflows and credentials of this user to when it is destroyed. Without it they are deleted with the user.",
+				Optional:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The creation date of the user.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "The last update date of the user.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures the role is a global role that can be assigned.
+func (r *userResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var role types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("role"), &role)...)
+	if resp.Diagnostics.HasError() || role.IsNull() || role.IsUnknown() {
+		return
+	}
+
+	for _, globalRole := range globalRoles {
+		if role.ValueString() == globalRole {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(
+		path.Root("role"),
+		"Invalid Global Role",
+		fmt.Sprintf("The role must be one of %s, got %q.", strings.Join(globalRoles, ", "), role.ValueString()),
+	)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan userResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Invite new user
+	invitation, err := r.client.inviteUser(ctx, plan.Email.ValueString(), plan.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create n8n User",
+			err.Error(),
+		)
+		return
+	}
+
+	// The invitation only contains the ID and email of the user. The user
+	// exists from here on, so a failed read must not leave it out of state.
+	u, err := r.client.getUser(ctx, invitation.User.ID)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Read n8n User",
+			"The user was invited, but reading it back failed. The remaining attributes are refreshed on the next plan: "+err.Error(),
+		)
+		plan.user = user{
+			ID:        types.StringValue(invitation.User.ID),
+			Email:     plan.Email,
+			FirstName: types.StringNull(),
+			LastName:  types.StringNull(),
+			Role:      plan.Role,
+			IsPending: types.BoolValue(true),
+			CreatedAt: types.StringNull(),
+			UpdatedAt: types.StringNull(),
+		}
+	} else {
+		plan.user = flattenUser(u)
+	}
+	plan.InviteAcceptURL = types.StringValue(invitation.User.InviteAcceptURL)
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Keep the prior state while the provider configuration is unknown
+	if r.client == nil {
+		return
+	}
+
+	var state userResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed user value from n8n
+	u, err := r.client.getUser(ctx, state.ID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n User",
+			err.Error(),
+		)
+		return
+	}
+
+	state.user = flattenUser(u)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state userResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Change role of existing user
+	if !plan.Role.Equal(state.Role) {
+		if err := r.client.changeUserRole(ctx, state.ID.ValueString(), plan.Role.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Update n8n User",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	u, err := r.client.getUser(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n User",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.user = flattenUser(u)
+
+	// Set state
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state userResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing user
+	err := r.client.deleteUser(ctx, state.ID.ValueString(), state.TransferToUserID.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete n8n User",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports an existing user by its ID or email address.
+func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &usersDataSource{}
+	_ datasource.DataSourceWithConfigure = &usersDataSource{}
+)
+
+// NewUsersDataSource is a helper function to simplify the provider implementation.
+func NewUsersDataSource() datasource.DataSource {
+	return &usersDataSource{}
+}
+
+// usersDataSource is the data source implementation.
+type usersDataSource struct {
+	client *client
+}
+
+// usersDataSourceModel maps the data source schema data.
+type usersDataSourceModel struct {
+	Users []user `tfsdk:"users"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *usersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *usersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+// Schema defines the schema for the data source.
+func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all users.",
+		Attributes: map[string]schema.Attribute{
+			"users": schema.ListNestedAttribute{
+				Description: "The users of the n8n instance.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "User ID",
+							Computed:    true,
+						},
+						"email": schema.StringAttribute{
+							Description: "The email address of the user.",
+							Computed:    true,
+						},
+						"first_name": schema.StringAttribute{
+							Description: "The first name of the user.",
+							Computed:    true,
+						},
+						"last_name": schema.StringAttribute{
+							Description: "The last name of the user.",
+							Computed:    true,
+						},
+						"role": schema.StringAttribute{
+							Description: "The global role of the user.",
+							Computed:    true,
+						},
+						"is_pending": schema.BoolAttribute{
+							Description: "Whether the user has not accepted the invitation yet.",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "The creation date of the user.",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "The last update date of the user.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *usersDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredClientError(&resp.Diagnostics)
+		return
+	}
+
+	users, err := d.client.listUsers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n Users",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	state := usersDataSourceModel{
+		Users: make([]user, len(users)),
+	}
+	for i, u := range users {
+		state.Users[i] = flattenUser(&u)
+	}
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &variablesDataSource{}
+	_ datasource.DataSourceWithConfigure = &variablesDataSource{}
+)
+
+// NewVariablesDataSource is a helper function to simplify the provider implementation.
+func NewVariablesDataSource() datasource.DataSource {
+	return &variablesDataSource{}
+}
+
+// variablesDataSource is the data source implementation.
+type variablesDataSource struct {
+	client *client
+}
+
+// variablesDataSourceModel maps the data source schema data.
+type variablesDataSourceModel struct {
+	Variables []variable              `tfsdk:"variables"`
+	Values    map[string]types.String `tfsdk:"values"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *variablesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *variablesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variables"
+}
+
+// Schema defines the schema for the data source.
+func (d *variablesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all variables.",
+		Attributes: map[string]schema.Attribute{
+			"variables": schema.ListNestedAttribute{
+				Description: "The variables of the n8n instance.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Variable ID",
+							Computed:    true,
+						},
+						"key": schema.StringAttribute{
+							Description: "The key of the variable.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "The value of the variable.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the variable.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"values": schema.MapAttribute{
+				Description: "The variable values by key.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *variablesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredClientError(&resp.Diagnostics)
+		return
+	}
+
+	variables, err := d.client.listVariables(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n Variables",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	state := variablesDataSourceModel{
+		Variables: make([]variable, len(variables)),
+		Values:    make(map[string]types.String, len(variables)),
+	}
+	for i, v := range variables {
+		state.Variables[i] = flattenVariable(&v)
+		state.Values[v.Key] = types.StringValue(v.Value)
+	}
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/edenreich/n8n-cli/n8n"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &workflowActivationResource{}
+	_ resource.ResourceWithConfigure   = &workflowActivationResource{}
+	_ resource.ResourceWithImportState = &workflowActivationResource{}
+)
+
+// NewWorkflowActivationResource is a helper function to simplify the provider implementation.
+func NewWorkflowActivationResource() resource.Resource {
+	return &workflowActivationResource{}
+}
+
+// workflowActivationResource is the resource implementation.
+type workflowActivationResource struct {
+	client *client
+}
+
+// workflowActivationResourceModel maps the resource schema data.
+type workflowActivationResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	WorkflowID types.String `tfsdk:"workflow_id"`
+	Active     types.Bool   `tfsdk:"active"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *workflowActivationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *workflowActivationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_activation"
+}
+
+// Schema defines the schema for the resource.
+func (r *workflowActivationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages whether a workflow is active. Destroying the resource deactivates the workflow without deleting it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Workflow ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_id": schema.StringAttribute{
+				Description: "The ID of the workflow to activate.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Description: "Whether the workflow is active. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *workflowActivationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan workflowActivationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setActive(ctx, &plan, &resp.State, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *workflowActivationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Keep the prior state while the provider configuration is unknown
+	if r.client == nil {
+		return
+	}
+
+	var state workflowActivationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed workflow value from n8n
+	workflow, err := r.client.getWorkflow(ctx, state.ID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n Workflow",
+			err.Error(),
+		)
+		return
+	}
+
+	state.WorkflowID = state.ID
+	state.Active = types.BoolValue(workflow.Active.ValueBool())
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *workflowActivationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan workflowActivationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setActive(ctx, &plan, &resp.State, &resp.Diagnostics)
+}
+
+// Delete deactivates the workflow.
+func (r *workflowActivationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state workflowActivationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.deactivateWorkflow(ctx, state.ID.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Deactivate n8n Workflow",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the activation of an existing workflow by the workflow ID.
+func (r *workflowActivationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// setActive activates or deactivates the workflow as planned and saves the result to state.
+func (r *workflowActivationResource) setActive(ctx context.Context, plan *workflowActivationResourceModel, state *tfsdk.State, diags *diag.Diagnostics) {
+	workflowID := plan.WorkflowID.ValueString()
+
+	var workflow *n8n.Workflow
+	var err error
+	if plan.Active.ValueBool() {
+		workflow, err = r.client.activateWorkflow(ctx, workflowID)
+	} else {
+		workflow, err = r.client.deactivateWorkflow(ctx, workflowID)
+	}
+	if err != nil {
+		var apiErr *apiError
+		if plan.Active.ValueBool() && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+			diags.AddAttributeError(
+				path.Root("active"),
+				"Unable to Activate n8n Workflow",
+				fmt.Sprintf("n8n rejected the activation of workflow %s: %s\n\n"+
+					"Active workflows need at least one trigger, poller or webhook node, "+
+					"and all credentials they use must exist.", workflowID, apiErr.Message()),
+			)
+			return
+		}
+		diags.AddError(
+			"Unable to Change n8n Workflow Activation",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = plan.WorkflowID
+	plan.Active = types.BoolValue(workflow.Active != nil && *workflow.Active)
+
+	// Set state
+	diags.Append(state.Set(ctx, plan)...)
+}
@@ -3,16 +3,22 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/edenreich/n8n-cli/n8n"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &workflowDataSource{}
-	_ datasource.DataSourceWithConfigure = &workflowDataSource{}
+	_ datasource.DataSource                   = &workflowDataSource{}
+	_ datasource.DataSourceWithConfigure      = &workflowDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &workflowDataSource{}
 )
 
 // NewWorkflowDataSource is a helper function to simplify the provider implementation.
@@ -27,44 +33,51 @@ type workflowDataSource struct {
 
 // workflowDataSourceModel maps the data source schema data and the API response.
 type workflowDataSourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Active      types.Bool   `tfsdk:"active"`
-	Nodes       []node       `tfsdk:"nodes"`
-	Connections types.Map    `tfsdk:"connections"`
-	Settings    settings     `tfsdk:"settings"`
-	StaticData  types.String `tfsdk:"static_data"`
-	Tags        []tag        `tfsdk:"tags"`
-	CreatedAt   types.String `tfsdk:"created_at"`
-	UpdatedAt   types.String `tfsdk:"updated_at"`
+	ID           types.String      `tfsdk:"id"`
+	Name         types.String      `tfsdk:"name"`
+	Active       types.Bool        `tfsdk:"active"`
+	Nodes        []node            `tfsdk:"nodes"`
+	Connections  []connection      `tfsdk:"connections"`
+	Settings     *settings         `tfsdk:"settings"`
+	StaticData   types.String      `tfsdk:"static_data"`
+	Tags         []tag             `tfsdk:"tags"`
+	WorkflowJSON workflowJSONValue `tfsdk:"workflow_json"`
+	CreatedAt    types.String      `tfsdk:"created_at"`
+	UpdatedAt    types.String      `tfsdk:"updated_at"`
 }
 
 // node represents a node in a workflow.
 type node struct {
-	ID               types.String  `tfsdk:"id"`
-	Name             types.String  `tfsdk:"name"`
-	WebhookID        types.String  `tfsdk:"webhook_id"`
-	Disabled         types.Bool    `tfsdk:"disabled"`
-	NotesInFlow      types.Bool    `tfsdk:"notes_in_flow"`
-	Notes            types.String  `tfsdk:"notes"`
-	Type             types.String  `tfsdk:"type"`
-	TypeVersion      types.Int64   `tfsdk:"type_version"`
-	ExecuteOnce      types.Bool    `tfsdk:"execute_once"`
-	AlwaysOutputData types.Bool    `tfsdk:"always_output_data"`
-	RetryOnFail      types.Bool    `tfsdk:"retry_on_fail"`
-	MaxTries         types.Int64   `tfsdk:"max_tries"`
-	WaitBetweenTries types.Int64   `tfsdk:"wait_between_tries"`
-	ContinueOnFail   types.Bool    `tfsdk:"continue_on_fail"`
-	OnError          types.String  `tfsdk:"on_error"`
-	Position         []types.Int64 `tfsdk:"position"`
-	Parameters       types.Map     `tfsdk:"parameters"`
-	Credentials      types.Map     `tfsdk:"credentials"`
-	CreatedAt        types.String  `tfsdk:"created_at"`
-	UpdatedAt        types.String  `tfsdk:"updated_at"`
+	ID               types.String         `tfsdk:"id"`
+	Name             types.String         `tfsdk:"name"`
+	WebhookID        types.String         `tfsdk:"webhook_id"`
+	Disabled         types.Bool           `tfsdk:"disabled"`
+	NotesInFlow      types.Bool           `tfsdk:"notes_in_flow"`
+	Notes            types.String         `tfsdk:"notes"`
+	Type             types.String         `tfsdk:"type"`
+	TypeVersion      types.Float64        `tfsdk:"type_version"`
+	ExecuteOnce      types.Bool           `tfsdk:"execute_once"`
+	AlwaysOutputData types.Bool           `tfsdk:"always_output_data"`
+	RetryOnFail      types.Bool           `tfsdk:"retry_on_fail"`
+	MaxTries         types.Int64          `tfsdk:"max_tries"`
+	WaitBetweenTries types.Int64          `tfsdk:"wait_between_tries"`
+	ContinueOnFail   types.Bool           `tfsdk:"continue_on_fail"`
+	OnError          types.String         `tfsdk:"on_error"`
+	Position         []types.Int64        `tfsdk:"position"`
+	Parameters       jsontypes.Normalized `tfsdk:"parameters"`
+	Credentials      jsontypes.Normalized `tfsdk:"credentials"`
+	CreatedAt        types.String         `tfsdk:"created_at"`
+	UpdatedAt        types.String         `tfsdk:"updated_at"`
 }
 
-// connections represents the connections in a workflow.
-type connections map[string]interface{}
+// connection represents a connection between two nodes in a workflow.
+type connection struct {
+	SourceNode  types.String `tfsdk:"source_node"`
+	OutputType  types.String `tfsdk:"output_type"`
+	OutputIndex types.Int64  `tfsdk:"output_index"`
+	TargetNode  types.String `tfsdk:"target_node"`
+	TargetIndex types.Int64  `tfsdk:"target_index"`
+}
 
 // settings represents the settings of a workflow.
 type settings struct {
@@ -114,11 +127,13 @@ func (d *workflowDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 		Description: "Manages a workflow.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Workflow ID",
-				Required:    true,
+				Description: "Workflow ID. Conflicts with `name`.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "The name of the workflow.",
+				Description: "The name of the workflow. Looks the workflow up by name when `id` is not set; the name must match exactly one workflow.",
+				Optional:    true,
 				Computed:    true,
 			},
 			"active": schema.BoolAttribute{
@@ -158,7 +173,7 @@ func (d *workflowDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 							Description: "Node type",
 							Computed:    true,
 						},
-						"type_version": schema.Int64Attribute{
+						"type_version": schema.Float64Attribute{
 							Description: "Node type version",
 							Computed:    true,
 						},
@@ -195,14 +210,14 @@ func (d *workflowDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 							ElementType: types.Int64Type,
 							Computed:    true,
 						},
-						"parameters": schema.MapAttribute{
-							Description: "Node parameters",
-							ElementType: types.StringType,
+						"parameters": schema.StringAttribute{
+							Description: "Node parameters as JSON.",
+							CustomType:  jsontypes.NormalizedType{},
 							Computed:    true,
 						},
-						"credentials": schema.MapAttribute{
-							Description: "Node credentials",
-							ElementType: types.StringType,
+						"credentials": schema.StringAttribute{
+							Description: "Node credentials as JSON, keyed by credential type.",
+							CustomType:  jsontypes.NormalizedType{},
 							Computed:    true,
 						},
 						"created_at": schema.StringAttribute{
@@ -216,10 +231,33 @@ func (d *workflowDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 					},
 				},
 			},
-			"connections": schema.MapAttribute{
+			"connections": schema.ListNestedAttribute{
 				Description: "The connections of the workflow.",
-				ElementType: types.StringType,
 				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_node": schema.StringAttribute{
+							Description: "Name of the node the connection starts from.",
+							Computed:    true,
+						},
+						"output_type": schema.StringAttribute{
+							Description: "Type of the source output, e.g. `main`.",
+							Computed:    true,
+						},
+						"output_index": schema.Int64Attribute{
+							Description: "Index of the source output.",
+							Computed:    true,
+						},
+						"target_node": schema.StringAttribute{
+							Description: "Name of the node the connection leads to.",
+							Computed:    true,
+						},
+						"target_index": schema.Int64Attribute{
+							Description: "Index of the target input.",
+							Computed:    true,
+						},
+					},
+				},
 			},
 			"settings": schema.SingleNestedAttribute{
 				Description: "The settings of the workflow.",
@@ -287,6 +325,11 @@ func (d *workflowDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 					},
 				},
 			},
+			"workflow_json": schema.StringAttribute{
+				Description: "The workflow as a normalized JSON document, without volatile fields such as ids and timestamps.",
+				CustomType:  workflowJSONType{},
+				Computed:    true,
+			},
 			"created_at": schema.StringAttribute{
 				Description: "The creation date of the workflow.",
 				Computed:    true,
@@ -299,8 +342,38 @@ func (d *workflowDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 	}
 }
 
+// ValidateConfig ensures exactly one of id and name is configured.
+func (d *workflowDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var id, name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case id.IsNull() && name.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Missing Workflow Lookup Key",
+			"Either id or name must be configured.",
+		)
+	case !id.IsNull() && !name.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Conflicting Workflow Lookup Keys",
+			"Only one of id and name can be configured.",
+		)
+	}
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (d *workflowDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredClientError(&resp.Diagnostics)
+		return
+	}
+
 	var state workflowDataSourceModel
 	diags := req.Config.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -308,8 +381,18 @@ func (d *workflowDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
+	workflowID := state.ID.ValueString()
+	if state.ID.IsNull() {
+		id, diags := d.lookupWorkflowID(ctx, state.Name.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		workflowID = id
+	}
+
 	// Get refreshed workflow value from n8n
-	workflowResponse, err := d.client.getWorkflow(ctx, state.ID.ValueString())
+	workflowResponse, err := d.client.getWorkflow(ctx, workflowID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read n8n Workflow",
@@ -325,3 +408,44 @@ func (d *workflowDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 }
+
+// lookupWorkflowID returns the ID of the only workflow with the given name.
+func (d *workflowDataSource) lookupWorkflowID(ctx context.Context, name string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	workflows, err := d.client.listWorkflows(ctx, n8n.GetWorkflowsParams{Name: &name})
+	if err != nil {
+		diags.AddError(
+			"Unable to Read n8n Workflow",
+			err.Error(),
+		)
+		return "", diags
+	}
+
+	// The API filter may match partially, so compare names exactly
+	var ids []string
+	for _, workflow := range workflows {
+		if workflow.Name == name && workflow.Id != nil {
+			ids = append(ids, *workflow.Id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Workflow Not Found",
+			fmt.Sprintf("No workflow is named %q.", name),
+		)
+	case 1:
+		return ids[0], diags
+	default:
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Ambiguous Workflow Name",
+			fmt.Sprintf("%d workflows are named %q (IDs: %s). Look the workflow up by id instead.", len(ids), name, strings.Join(ids, ", ")),
+		)
+	}
+
+	return "", diags
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = workflowJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = workflowJSONValue{}
+)
+
+// workflowJSONType is a JSON string holding a workflow as exported by the n8n editor.
+type workflowJSONType struct {
+	jsontypes.NormalizedType
+}
+
+// String returns a human readable string of the type name.
+func (t workflowJSONType) String() string {
+	return "workflowJSONType"
+}
+
+// ValueType returns the Value type.
+func (t workflowJSONType) ValueType(_ context.Context) attr.Value {
+	return workflowJSONValue{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t workflowJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(workflowJSONType)
+	if !ok {
+		return false
+	}
+
+	return t.NormalizedType.Equal(other.NormalizedType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t workflowJSONType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return workflowJSONValue{
+		Normalized: jsontypes.Normalized{StringValue: in},
+	}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t workflowJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// workflowJSONValue is the value of a workflowJSONType. Two values are
+// semantically equal when they describe the same workflow once volatile
+// fields are stripped, regardless of whitespace or key order.
+type workflowJSONValue struct {
+	jsontypes.Normalized
+}
+
+// newWorkflowJSONValue creates a workflowJSONValue with a known value.
+func newWorkflowJSONValue(value string) workflowJSONValue {
+	return workflowJSONValue{Normalized: jsontypes.NewNormalizedValue(value)}
+}
+
+// newWorkflowJSONNull creates a workflowJSONValue with a null value.
+func newWorkflowJSONNull() workflowJSONValue {
+	return workflowJSONValue{Normalized: jsontypes.NewNormalizedNull()}
+}
+
+// Type returns a workflowJSONType.
+func (v workflowJSONValue) Type(_ context.Context) attr.Type {
+	return workflowJSONType{}
+}
+
+// Equal returns true if the given value is equivalent.
+func (v workflowJSONValue) Equal(o attr.Value) bool {
+	other, ok := o.(workflowJSONValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if both values describe the same workflow.
+func (v workflowJSONValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(workflowJSONValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+
+	oldNormalized, err := normalizeWorkflowJSON(v.ValueString())
+	if err != nil {
+		// Invalid JSON is reported by attribute validation
+		return false, diags
+	}
+	newNormalized, err := normalizeWorkflowJSON(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	var oldDoc, newDoc interface{}
+	if err := json.Unmarshal([]byte(oldNormalized), &oldDoc); err != nil {
+		return false, diags
+	}
+	if err := json.Unmarshal([]byte(newNormalized), &newDoc); err != nil {
+		return false, diags
+	}
+
+	return reflect.DeepEqual(oldDoc, newDoc), diags
+}
+
+// workflowJSONServerKeys are the top-level fields of a workflow export that
+// are managed by n8n or only meaningful to the editor. The API rejects or
+// ignores them, so they are dropped from configured exports and API responses
+// alike.
+var workflowJSONServerKeys = []string{
+	"id",
+	"active",
+	"createdAt",
+	"updatedAt",
+	"tags",
+	"versionId",
+	"pinData",
+	"meta",
+	"triggerCount",
+	"shared",
+	"isArchived",
+}
+
+// decodeWorkflowJSON parses a workflow export into the body of a workflow
+// create or update request. Server-managed fields, see workflowJSONServerKeys,
+// are dropped; every other field, including settings and node fields unknown
+// to this provider, is kept verbatim.
+func decodeWorkflowJSON(raw string) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.UseNumber()
+
+	var workflow map[string]interface{}
+	if err := decoder.Decode(&workflow); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow JSON: %w", err)
+	}
+	if workflow == nil {
+		return nil, fmt.Errorf("failed to decode workflow JSON: expected an object")
+	}
+
+	for _, key := range workflowJSONServerKeys {
+		delete(workflow, key)
+	}
+	if nodes, ok := workflow["nodes"].([]interface{}); ok {
+		for _, n := range nodes {
+			if nodeMap, ok := n.(map[string]interface{}); ok {
+				delete(nodeMap, "createdAt")
+				delete(nodeMap, "updatedAt")
+			}
+		}
+	}
+	if workflow["connections"] == nil {
+		workflow["connections"] = map[string]interface{}{}
+	}
+	if workflow["settings"] == nil {
+		workflow["settings"] = map[string]interface{}{}
+	}
+
+	return workflow, nil
+}
+
+// normalizeWorkflowJSON strips server-managed fields from a workflow export
+// and renders it with sorted keys.
+func normalizeWorkflowJSON(raw string) (string, error) {
+	workflow, err := decodeWorkflowJSON(raw)
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(workflow)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode workflow JSON: %w", err)
+	}
+
+	return string(encoded), nil
+}
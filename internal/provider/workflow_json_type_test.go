@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestWorkflowJSONValueStringSemanticEquals(t *testing.T) {
+	exported := `{
+  "name": "Example",
+  "nodes": [{"id": "node-1", "name": "Manual Trigger", "type": "n8n-nodes-base.manualTrigger", "typeVersion": 1, "position": [0, 0], "parameters": {}}],
+  "connections": {},
+  "settings": {"executionOrder": "v1"},
+  "pinData": {"Manual Trigger": [{"json": {}}]},
+  "versionId": "3f7c2d1e",
+  "meta": {"instanceId": "abc"},
+  "id": "wf-1",
+  "createdAt": "2025-01-02T03:04:05.000Z",
+  "updatedAt": "2025-01-02T03:04:05.000Z"
+}`
+
+	testCases := map[string]struct {
+		other    string
+		expected bool
+	}{
+		"volatile fields and key order ignored": {
+			other:    `{"settings":{"executionOrder":"v1"},"connections":{},"nodes":[{"parameters":{},"position":[0,0],"typeVersion":1,"type":"n8n-nodes-base.manualTrigger","name":"Manual Trigger","id":"node-1"}],"name":"Example","id":"wf-2","versionId":"other"}`,
+			expected: true,
+		},
+		"renamed": {
+			other:    `{"name":"Renamed","nodes":[{"id":"node-1","name":"Manual Trigger","type":"n8n-nodes-base.manualTrigger","typeVersion":1,"position":[0,0],"parameters":{}}],"connections":{},"settings":{"executionOrder":"v1"}}`,
+			expected: false,
+		},
+		"node moved": {
+			other:    `{"name":"Example","nodes":[{"id":"node-1","name":"Manual Trigger","type":"n8n-nodes-base.manualTrigger","typeVersion":1,"position":[200,0],"parameters":{}}],"connections":{},"settings":{"executionOrder":"v1"}}`,
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := newWorkflowJSONValue(exported).StringSemanticEquals(context.Background(), newWorkflowJSONValue(testCase.other))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != testCase.expected {
+				t.Errorf("StringSemanticEquals() = %t, want %t", equal, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestDecodeWorkflowJSON(t *testing.T) {
+	workflow, err := decodeWorkflowJSON(`{"id":"wf-1","name":"Example","active":true,"nodes":[{"name":"Start","createdAt":"2025-01-02T03:04:05.000Z","retryOnFail":true,"futureField":1}],"settings":{"executionOrder":"v1","callerPolicy":"workflowsFromSameOwner"},"staticData":{"lastId":9007199254740993},"pinData":{"x":[]},"versionId":"v","tags":[{"id":"t","name":"n"}]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, field := range []string{"id", "pinData", "versionId", "active", "tags"} {
+		if _, ok := workflow[field]; ok {
+			t.Errorf("field %q not stripped", field)
+		}
+	}
+	if _, ok := workflow["connections"].(map[string]interface{}); !ok {
+		t.Error("connections must default to an empty object")
+	}
+
+	encoded, err := json.Marshal(workflow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"connections":{},"name":"Example","nodes":[{"futureField":1,"name":"Start","retryOnFail":true}],"settings":{"callerPolicy":"workflowsFromSameOwner","executionOrder":"v1"},"staticData":{"lastId":9007199254740993}}`
+	if string(encoded) != expected {
+		t.Errorf("workflow = %s, want %s", encoded, expected)
+	}
+}
+
+func TestFlattenWorkflowResponseMatchesExport(t *testing.T) {
+	callerPolicyExport := `{"name":"Example","nodes":[],"connections":{},"settings":{"executionOrder":"v1","callerPolicy":"workflowsFromSameOwner"}}`
+
+	testCases := map[string]struct {
+		exported string
+		response string
+		expected bool
+	}{
+		"export": {
+			exported: testWorkflowJSON,
+			response: testWorkflowJSON,
+			expected: true,
+		},
+		"unknown settings kept": {
+			exported: callerPolicyExport,
+			response: `{"id":"wf-1","name":"Example","active":false,"nodes":[],"connections":{},"settings":{"callerPolicy":"workflowsFromSameOwner","executionOrder":"v1"},"createdAt":"2025-01-02T03:04:05.000Z","updatedAt":"2025-01-02T03:04:05.000Z","versionId":"v","tags":[]}`,
+			expected: true,
+		},
+		"unknown settings changed": {
+			exported: callerPolicyExport,
+			response: `{"id":"wf-1","name":"Example","nodes":[],"connections":{},"settings":{"callerPolicy":"any","executionOrder":"v1"}}`,
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wfModel, err := flattenWorkflowResponse(json.RawMessage(testCase.response))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			equal, diags := newWorkflowJSONValue(testCase.exported).StringSemanticEquals(context.Background(), wfModel.WorkflowJSON)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != testCase.expected {
+				t.Errorf("StringSemanticEquals() = %t, want %t for %s", equal, testCase.expected, wfModel.WorkflowJSON.ValueString())
+			}
+		})
+	}
+}
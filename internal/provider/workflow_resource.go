@@ -0,0 +1,704 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &workflowResource{}
+	_ resource.ResourceWithConfigure      = &workflowResource{}
+	_ resource.ResourceWithImportState    = &workflowResource{}
+	_ resource.ResourceWithValidateConfig = &workflowResource{}
+)
+
+// NewWorkflowResource is a helper function to simplify the provider implementation.
+func NewWorkflowResource() resource.Resource {
+	return &workflowResource{}
+}
+
+// workflowResource is the resource implementation.
+type workflowResource struct {
+	client *client
+}
+
+// workflowResourceModel maps the resource schema data.
+type workflowResourceModel struct {
+	workflowDataSourceModel
+	ProjectID types.String `tfsdk:"project_id"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *workflowResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *workflowResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow"
+}
+
+// Schema defines the schema for the resource.
+func (r *workflowResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a workflow.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Workflow ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the workflow. Required unless `workflow_json` is set, which then provides the name.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"active": schema.BoolAttribute{
+				Description: "Whether the workflow is active.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"nodes": schema.ListNestedAttribute{
+				Description: "The nodes of the workflow. Conflicts with `workflow_json`.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Node ID",
+							Optional:    true,
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								useNodeStateForUnknown{},
+							},
+						},
+						"name": schema.StringAttribute{
+							Description: "Node name",
+							Required:    true,
+						},
+						"webhook_id": schema.StringAttribute{
+							Description: "Webhook ID",
+							Optional:    true,
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								useNodeStateForUnknown{},
+							},
+						},
+						"disabled": schema.BoolAttribute{
+							Description: "Whether the node is disabled.",
+							Optional:    true,
+						},
+						"notes_in_flow": schema.BoolAttribute{
+							Description: "Whether the node has notes in the flow.",
+							Optional:    true,
+						},
+						"notes": schema.StringAttribute{
+							Description: "Node notes",
+							Optional:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Node type",
+							Required:    true,
+						},
+						"type_version": schema.Float64Attribute{
+							Description: "Node type version",
+							Required:    true,
+						},
+						"execute_once": schema.BoolAttribute{
+							Description: "Whether the node executes only once.",
+							Optional:    true,
+						},
+						"always_output_data": schema.BoolAttribute{
+							Description: "Whether the node always outputs data.",
+							Optional:    true,
+						},
+						"retry_on_fail": schema.BoolAttribute{
+							Description: "Whether the node retries on fail.",
+							Optional:    true,
+						},
+						"max_tries": schema.Int64Attribute{
+							Description: "Max tries for the node.",
+							Optional:    true,
+						},
+						"wait_between_tries": schema.Int64Attribute{
+							Description: "Wait between tries for the node.",
+							Optional:    true,
+						},
+						"continue_on_fail": schema.BoolAttribute{
+							Description: "Whether the node continues on fail.",
+							Optional:    true,
+						},
+						"on_error": schema.StringAttribute{
+							Description: "On error action for the node.",
+							Optional:    true,
+						},
+						"position": schema.ListAttribute{
+							Description: "Node position",
+							ElementType: types.Int64Type,
+							Required:    true,
+						},
+						"parameters": schema.StringAttribute{
+							Description: "Node parameters as JSON, for example `jsonencode({ url = \"https://example.com\" })`.",
+							CustomType:  jsontypes.NormalizedType{},
+							Optional:    true,
+							Computed:    true,
+						},
+						"credentials": schema.StringAttribute{
+							Description: "Node credentials as JSON, keyed by credential type, for example `jsonencode({ slackApi = { id = \"1\", name = \"Slack\" } })`.",
+							CustomType:  jsontypes.NormalizedType{},
+							Optional:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Node creation date",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "Node last update date",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"connections": schema.SetNestedAttribute{
+				Description: "The connections of the workflow. Conflicts with `workflow_json`.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_node": schema.StringAttribute{
+							Description: "Name of the node the connection starts from.",
+							Required:    true,
+						},
+						"output_type": schema.StringAttribute{
+							Description: "Type of the source output. The target input has the same type.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("main"),
+						},
+						"output_index": schema.Int64Attribute{
+							Description: "Index of the source output, e.g. `1` for the false branch of an IF node.",
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(0),
+						},
+						"target_node": schema.StringAttribute{
+							Description: "Name of the node the connection leads to.",
+							Required:    true,
+						},
+						"target_index": schema.Int64Attribute{
+							Description: "Index of the target input, e.g. `1` for the second input of a Merge node.",
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(0),
+						},
+					},
+				},
+			},
+			"settings": schema.SingleNestedAttribute{
+				Description: "The settings of the workflow. Settings are only tracked when configured. Conflicts with `workflow_json`.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"save_execution_progress": schema.BoolAttribute{
+						Description: "Whether to save execution progress.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"save_manual_executions": schema.BoolAttribute{
+						Description: "Whether to save manual executions.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"save_data_error_execution": schema.StringAttribute{
+						Description: "Save data on error execution.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"save_data_success_execution": schema.StringAttribute{
+						Description: "Save data on success execution.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"execution_timeout": schema.Int64Attribute{
+						Description: "Execution timeout.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"error_workflow": schema.StringAttribute{
+						Description: "Error workflow.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"timezone": schema.StringAttribute{
+						Description: "Timezone.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"execution_order": schema.StringAttribute{
+						Description: "Execution order.",
+						Optional:    true,
+						Computed:    true,
+					},
+				},
+			},
+			"static_data": schema.StringAttribute{
+				Description: "The static data of the workflow as JSON. Static data is only tracked when configured. Conflicts with `workflow_json`.",
+				Optional:    true,
+			},
+			"tags": schema.ListNestedAttribute{
+				Description: "The tags of the workflow. Tags are only managed when configured.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Tag ID",
+							Required:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Tag name",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Tag creation date",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "Tag last update date",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"workflow_json": schema.StringAttribute{
+				Description: "A workflow as exported from the n8n editor. Volatile fields such as `id`, `createdAt`, `updatedAt`, `versionId` and `pinData` are ignored; all other fields are sent as exported. Conflicts with `nodes`.",
+				CustomType:  workflowJSONType{},
+				Optional:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "The project the workflow belongs to. Defaults to the personal project of the API key owner. Changing it transfers the workflow; removing it leaves the workflow where it is. " +
+					"The n8n API does not return the project of a workflow, so transfers made outside of Terraform are not detected as drift.",
+				Optional: true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The creation date of the workflow.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "The last update date of the workflow.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures the workflow is defined either by its attributes or by a workflow export.
+func (r *workflowResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var workflowJSON workflowJSONValue
+	var nodes types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("workflow_json"), &workflowJSON)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("nodes"), &nodes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if workflowJSON.IsNull() {
+		if nodes.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("nodes"),
+				"Missing Workflow Definition",
+				"Either nodes or workflow_json must be configured.",
+			)
+			return
+		}
+
+		var name types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+		if name.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Missing Workflow Name",
+				"The name attribute is required when the workflow is defined by nodes.",
+			)
+		}
+		return
+	}
+
+	var name, staticData types.String
+	var connections types.Set
+	var settings types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connections"), &connections)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("settings"), &settings)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("static_data"), &staticData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conflicting := map[string]attr.Value{
+		"name":        name,
+		"nodes":       nodes,
+		"connections": connections,
+		"settings":    settings,
+		"static_data": staticData,
+	}
+	for attribute, value := range conflicting {
+		if !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Conflicting Workflow Definition",
+				fmt.Sprintf("The %s attribute cannot be configured together with workflow_json, which already defines it.", attribute),
+			)
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *workflowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan workflowResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new workflow
+	workflow, err := r.client.createWorkflow(ctx, &plan.workflowDataSourceModel)
+	if err != nil && workflow == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create n8n Workflow",
+			err.Error(),
+		)
+		return
+	}
+	keepUnmanagedWorkflowFields(workflow, &plan.workflowDataSourceModel)
+	state := workflowResourceModel{
+		workflowDataSourceModel: *workflow,
+		ProjectID:               types.StringNull(),
+	}
+	if err != nil {
+		// Keep the created workflow in state, so it is not left behind
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tags"),
+			"Unable to Create n8n Workflow",
+			err.Error(),
+		)
+		return
+	}
+
+	// Workflows are created in the personal project of the API key owner
+	if !plan.ProjectID.IsNull() {
+		if err := r.client.transferWorkflow(ctx, workflow.ID.ValueString(), plan.ProjectID.ValueString()); err != nil {
+			// Keep the created workflow in state, so it is not left behind
+			resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project_id"),
+				"Unable to Create n8n Workflow",
+				err.Error(),
+			)
+			return
+		}
+		state.ProjectID = plan.ProjectID
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *workflowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Keep the prior state while the provider configuration is unknown
+	if r.client == nil {
+		return
+	}
+
+	var state workflowResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed workflow value from n8n
+	workflow, err := r.client.getWorkflow(ctx, state.ID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n Workflow",
+			err.Error(),
+		)
+		return
+	}
+	keepUnmanagedWorkflowFields(workflow, &state.workflowDataSourceModel)
+	state.workflowDataSourceModel = *workflow
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *workflowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state workflowResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update existing workflow, unless only its project changes
+	if onlyProjectChanged(req.Plan.Raw, req.State.Raw) {
+		plan.workflowDataSourceModel = state.workflowDataSourceModel
+	} else {
+		workflow, err := r.client.updateWorkflow(ctx, state.ID.ValueString(), &plan.workflowDataSourceModel)
+		if err != nil && workflow == nil {
+			resp.Diagnostics.AddError(
+				"Unable to Update n8n Workflow",
+				err.Error(),
+			)
+			return
+		}
+		keepUnmanagedWorkflowFields(workflow, &plan.workflowDataSourceModel)
+		plan.workflowDataSourceModel = *workflow
+		if err != nil {
+			// Record the updated content, the project is left as it was
+			plan.ProjectID = state.ProjectID
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tags"),
+				"Unable to Update n8n Workflow",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Transfer in place, keeping the execution history and webhook IDs
+	if !plan.ProjectID.IsNull() && !plan.ProjectID.Equal(state.ProjectID) {
+		if err := r.client.transferWorkflow(ctx, state.ID.ValueString(), plan.ProjectID.ValueString()); err != nil {
+			plan.ProjectID = state.ProjectID
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project_id"),
+				"Unable to Transfer n8n Workflow",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *workflowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state workflowResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing workflow
+	err := r.client.deleteWorkflow(ctx, state.ID.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete n8n Workflow",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports an existing workflow by its ID.
+func (r *workflowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// useNodeStateForUnknown keeps the value of a computed node attribute, such as
+// the node or webhook ID, from the node of the same name in state. Matching by
+// name rather than list index keeps the IDs with their nodes when nodes are
+// added, removed or reordered.
+type useNodeStateForUnknown struct{}
+
+func (m useNodeStateForUnknown) Description(_ context.Context) string {
+	return "Keeps the value of the node with the same name in state."
+}
+
+func (m useNodeStateForUnknown) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useNodeStateForUnknown) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var name types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, req.Path.ParentPath().AtName("name"), &name)...)
+	var stateNodes []types.Object
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("nodes"), &stateNodes)...)
+	if resp.Diagnostics.HasError() || name.IsUnknown() {
+		return
+	}
+
+	attribute := req.Path.Steps()[len(req.Path.Steps())-1].String()
+	for _, stateNode := range stateNodes {
+		attributes := stateNode.Attributes()
+		if stateName, ok := attributes["name"].(types.String); !ok || !stateName.Equal(name) {
+			continue
+		}
+		if value, ok := attributes[attribute].(types.String); ok {
+			resp.PlanValue = value
+		}
+		return
+	}
+}
+
+// onlyProjectChanged reports whether the planned workflow differs from the
+// state in nothing but project_id. Unknown planned values are computed by n8n
+// and match any value in state.
+func onlyProjectChanged(plan, state tftypes.Value) bool {
+	var planAttributes, stateAttributes map[string]tftypes.Value
+	if plan.As(&planAttributes) != nil || state.As(&stateAttributes) != nil {
+		return false
+	}
+
+	for name, planValue := range planAttributes {
+		if name == "project_id" {
+			continue
+		}
+		if !knownValuesEqual(planValue, stateAttributes[name]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// knownValuesEqual reports whether the known parts of a planned value equal
+// the value in state. Set elements are matched regardless of their order.
+func knownValuesEqual(plan, state tftypes.Value) bool {
+	if !plan.IsKnown() {
+		return true
+	}
+	if !state.IsKnown() || plan.IsNull() || state.IsNull() {
+		return plan.IsNull() == state.IsNull() && state.IsKnown()
+	}
+
+	switch {
+	case plan.Type().Is(tftypes.Object{}), plan.Type().Is(tftypes.Map{}):
+		var planValues, stateValues map[string]tftypes.Value
+		if plan.As(&planValues) != nil || state.As(&stateValues) != nil || len(planValues) != len(stateValues) {
+			return false
+		}
+		for key, planValue := range planValues {
+			stateValue, ok := stateValues[key]
+			if !ok || !knownValuesEqual(planValue, stateValue) {
+				return false
+			}
+		}
+		return true
+	case plan.Type().Is(tftypes.List{}), plan.Type().Is(tftypes.Tuple{}):
+		var planValues, stateValues []tftypes.Value
+		if plan.As(&planValues) != nil || state.As(&stateValues) != nil || len(planValues) != len(stateValues) {
+			return false
+		}
+		for i := range planValues {
+			if !knownValuesEqual(planValues[i], stateValues[i]) {
+				return false
+			}
+		}
+		return true
+	case plan.Type().Is(tftypes.Set{}):
+		var planValues, stateValues []tftypes.Value
+		if plan.As(&planValues) != nil || state.As(&stateValues) != nil || len(planValues) != len(stateValues) {
+			return false
+		}
+		matched := make([]bool, len(stateValues))
+		for _, planValue := range planValues {
+			found := false
+			for i, stateValue := range stateValues {
+				if !matched[i] && knownValuesEqual(planValue, stateValue) {
+					matched[i], found = true, true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		return plan.Equal(state)
+	}
+}
+
+// keepUnmanagedWorkflowFields clears the optional attributes that are not
+// configured, so that values n8n fills in on its own do not show up as drift.
+// A workflow defined by an export is only tracked through workflow_json.
+func keepUnmanagedWorkflowFields(workflow, prior *workflowDataSourceModel) {
+	if prior.WorkflowJSON.IsNull() {
+		workflow.WorkflowJSON = newWorkflowJSONNull()
+	} else {
+		workflow.Nodes = nil
+		workflow.Connections = nil
+	}
+	if prior.Connections == nil && len(workflow.Connections) == 0 {
+		workflow.Connections = nil
+	}
+	if prior.Settings == nil {
+		workflow.Settings = nil
+	}
+	if prior.StaticData.IsNull() {
+		workflow.StaticData = types.StringNull()
+	}
+	if prior.Tags == nil {
+		workflow.Tags = nil
+	}
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOnlyProjectChanged(t *testing.T) {
+	connectionType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"source_node": tftypes.String,
+		"output_type": tftypes.String,
+	}}
+	workflowType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":        tftypes.String,
+		"project_id":  tftypes.String,
+		"updated_at":  tftypes.String,
+		"connections": tftypes.Set{ElementType: connectionType},
+	}}
+
+	connection := func(sourceNode string, outputType interface{}) tftypes.Value {
+		return tftypes.NewValue(connectionType, map[string]tftypes.Value{
+			"source_node": tftypes.NewValue(tftypes.String, sourceNode),
+			"output_type": tftypes.NewValue(tftypes.String, outputType),
+		})
+	}
+	workflow := func(name, projectID string, updatedAt interface{}, connections ...tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(workflowType, map[string]tftypes.Value{
+			"name":        tftypes.NewValue(tftypes.String, name),
+			"project_id":  tftypes.NewValue(tftypes.String, projectID),
+			"updated_at":  tftypes.NewValue(tftypes.String, updatedAt),
+			"connections": tftypes.NewValue(tftypes.Set{ElementType: connectionType}, connections),
+		})
+	}
+
+	state := workflow("Example", "project-1", "2025-01-02T03:04:05Z", connection("Start", "main"), connection("Set", "main"))
+
+	testCases := map[string]struct {
+		plan     tftypes.Value
+		expected bool
+	}{
+		"project changed": {
+			plan:     workflow("Example", "project-2", tftypes.UnknownValue, connection("Set", tftypes.UnknownValue), connection("Start", tftypes.UnknownValue)),
+			expected: true,
+		},
+		"name changed": {
+			plan:     workflow("Renamed", "project-2", tftypes.UnknownValue, connection("Start", "main"), connection("Set", "main")),
+			expected: false,
+		},
+		"connection changed": {
+			plan:     workflow("Example", "project-2", tftypes.UnknownValue, connection("Start", tftypes.UnknownValue), connection("Merge", tftypes.UnknownValue)),
+			expected: false,
+		},
+		"connection removed": {
+			plan:     workflow("Example", "project-2", tftypes.UnknownValue, connection("Start", tftypes.UnknownValue)),
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := onlyProjectChanged(testCase.plan, state); got != testCase.expected {
+				t.Errorf("onlyProjectChanged = %t, want %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestUseNodeStateForUnknown(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&workflowResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	workflow := func(nodes ...node) workflowResourceModel {
+		return workflowResourceModel{
+			workflowDataSourceModel: workflowDataSourceModel{
+				Name:         types.StringValue("Example"),
+				Nodes:        nodes,
+				WorkflowJSON: newWorkflowJSONNull(),
+			},
+		}
+	}
+	webhookNode := node{Name: types.StringValue("Webhook"), ID: types.StringValue("node-1"), WebhookID: types.StringValue("hook-1")}
+	setNode := node{Name: types.StringValue("Set"), ID: types.StringValue("node-2"), WebhookID: types.StringNull()}
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, workflow(webhookNode, setNode)); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	// A node inserted in front shifts the webhook node to the second index
+	inserted := node{Name: types.StringValue("Start"), ID: types.StringUnknown(), WebhookID: types.StringUnknown()}
+	moved := node{Name: types.StringValue("Webhook"), ID: types.StringUnknown(), WebhookID: types.StringUnknown()}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, workflow(inserted, moved)); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	testCases := map[string]struct {
+		index     int
+		attribute string
+		expected  types.String
+	}{
+		"moved node id": {
+			index:     1,
+			attribute: "id",
+			expected:  types.StringValue("node-1"),
+		},
+		"moved node webhook id": {
+			index:     1,
+			attribute: "webhook_id",
+			expected:  types.StringValue("hook-1"),
+		},
+		"new node id": {
+			index:     0,
+			attribute: "id",
+			expected:  types.StringUnknown(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				Path:        path.Root("nodes").AtListIndex(testCase.index).AtName(testCase.attribute),
+				Plan:        plan,
+				State:       state,
+				PlanValue:   types.StringUnknown(),
+				ConfigValue: types.StringNull(),
+			}
+			resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+			useNodeStateForUnknown{}.PlanModifyString(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(testCase.expected) {
+				t.Errorf("plan value = %s, want %s", resp.PlanValue, testCase.expected)
+			}
+		})
+	}
+}
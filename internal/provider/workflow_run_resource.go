@@ -0,0 +1,438 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// executionPollInterval is the time between two execution status checks.
+var executionPollInterval = 2 * time.Second
+
+// defaultWorkflowRunTimeout is the time to wait for an execution when timeout
+// is not set.
+const defaultWorkflowRunTimeout = 2 * time.Minute
+
+// workflowRunHeader carries the ID of a run on the webhook call. The webhook
+// node outputs the request headers, so the ID identifies the execution the
+// call started.
+const workflowRunHeader = "X-Terraform-Run-Id"
+
+// workflowRunSearchLimit is the number of most recent executions searched for
+// the ID of a run.
+const workflowRunSearchLimit = 20
+
+// webhookNodeType is the type of the Webhook trigger node.
+const webhookNodeType = "n8n-nodes-base.webhook"
+
+// finishedExecutionStatuses are the statuses of executions that have ended.
+var finishedExecutionStatuses = map[string]bool{
+	"success":  true,
+	"error":    true,
+	"crashed":  true,
+	"canceled": true,
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &workflowRunResource{}
+	_ resource.ResourceWithConfigure      = &workflowRunResource{}
+	_ resource.ResourceWithValidateConfig = &workflowRunResource{}
+)
+
+// NewWorkflowRunResource is a helper function to simplify the provider implementation.
+func NewWorkflowRunResource() resource.Resource {
+	return &workflowRunResource{}
+}
+
+// workflowRunResource is the resource implementation.
+type workflowRunResource struct {
+	client *client
+}
+
+// workflowRunResourceModel maps the resource schema data.
+type workflowRunResourceModel struct {
+	ID          types.String         `tfsdk:"id"`
+	WorkflowID  types.String         `tfsdk:"workflow_id"`
+	WebhookPath types.String         `tfsdk:"webhook_path"`
+	Method      types.String         `tfsdk:"method"`
+	Body        jsontypes.Normalized `tfsdk:"body"`
+	Triggers    types.Map            `tfsdk:"triggers"`
+	Timeout     types.String         `tfsdk:"timeout"`
+	Status      types.String         `tfsdk:"status"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *workflowRunResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *workflowRunResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_run"
+}
+
+// Schema defines the schema for the resource.
+func (r *workflowRunResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs an active workflow through its webhook and waits for the execution to finish, failing the apply if the execution fails. " +
+			"Meant as a smoke test after deploying a workflow. The workflow runs when the resource is created and again whenever `workflow_id`, `webhook_path`, `method`, `body` or `triggers` change, never during plan. " +
+			"The n8n API cannot start manual triggers, so the workflow needs a webhook trigger. " +
+			"The webhook call carries an `X-Terraform-Run-Id` header, which the Webhook node outputs and which identifies the execution it started, so the workflow must save both successful and failed executions with their data. Destroying the resource does nothing.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the execution.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_id": schema.StringAttribute{
+				Description: "The ID of the workflow to run.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"webhook_path": schema.StringAttribute{
+				Description: "The path of the webhook trigger of the workflow, as configured on the Webhook node.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"method": schema.StringAttribute{
+				Description: "The HTTP method of the webhook. Defaults to `POST`.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				Description: "A JSON body to send to the webhook.",
+				CustomType:  jsontypes.NormalizedType{},
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that run the workflow again when changed, such as the `updated_at` of the workflow.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "How long to wait for the execution to finish, e.g. `30s` or `5m`. Defaults to `2m`.",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the finished execution.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures the timeout is a positive duration.
+func (r *workflowRunResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var timeout types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("timeout"), &timeout)...)
+	if resp.Diagnostics.HasError() || timeout.IsNull() || timeout.IsUnknown() {
+		return
+	}
+
+	if _, err := parseWorkflowRunTimeout(timeout); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timeout"),
+			"Invalid Timeout",
+			err.Error(),
+		)
+	}
+}
+
+// Create runs the workflow, waits for its execution to finish and sets the
+// initial Terraform state.
+func (r *workflowRunResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan workflowRunResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := parseWorkflowRunTimeout(plan.Timeout)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timeout"),
+			"Invalid Timeout",
+			err.Error(),
+		)
+		return
+	}
+
+	method := http.MethodPost
+	if !plan.Method.IsNull() {
+		method = plan.Method.ValueString()
+	}
+
+	var body []byte
+	if !plan.Body.IsNull() {
+		body = []byte(plan.Body.ValueString())
+	}
+
+	runID, err := newWorkflowRunID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Run n8n Workflow",
+			err.Error(),
+		)
+		return
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	workflowID := plan.WorkflowID.ValueString()
+	e, err := r.runWorkflow(runCtx, workflowID, runID, method, plan.WebhookPath.ValueString(), body)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			resp.Diagnostics.AddError(
+				"Workflow Execution Timed Out",
+				fmt.Sprintf("The execution of workflow %s did not finish within %s. If it did finish, check that the n8n instance saves its executions.", workflowID, timeout),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Run n8n Workflow",
+			err.Error(),
+		)
+		return
+	}
+
+	if e.Status != "success" {
+		resp.Diagnostics.AddError(
+			"Workflow Execution Failed",
+			fmt.Sprintf("Execution %s of workflow %s ended with status %s: %s", e.ID, workflowID, e.Status, executionErrorMessage(e)),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(string(e.ID))
+	plan.Status = types.StringValue(e.Status)
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the prior Terraform state, as a run is an operation and not an object.
+func (r *workflowRunResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update records a changed timeout, which takes effect on the next run.
+func (r *workflowRunResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state workflowRunResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeout"), &state.Timeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the Terraform state; the execution is kept.
+func (r *workflowRunResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// runWorkflow calls the webhook of the workflow and waits until the execution
+// it started has finished. The execution is told apart from others of the same
+// workflow by the run ID sent along with the webhook call, which the Webhook
+// node outputs with the request headers. Executions are only saved with their
+// data once finished, so each new execution is fetched with its data once it
+// has finished and checked for the run ID.
+func (r *workflowRunResource) runWorkflow(ctx context.Context, workflowID, runID, method, webhookPath string, body []byte) (*apiExecution, error) {
+	workflow, err := r.client.getWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	webhookNodes, err := workflowRunWebhookNodes(workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	// Executions that exist before the webhook call were not started by it
+	checked := map[flexibleID]bool{}
+	previous, err := r.client.listExecutions(ctx, executionFilter{WorkflowID: workflowID, Limit: workflowRunSearchLimit})
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range previous {
+		checked[e.ID] = true
+	}
+
+	header := http.Header{workflowRunHeader: {runID}}
+	if err := r.client.triggerWebhook(ctx, method, webhookPath, header, body); err != nil {
+		return nil, err
+	}
+
+	for {
+		recent, err := r.client.listExecutions(ctx, executionFilter{WorkflowID: workflowID, Limit: workflowRunSearchLimit})
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range recent {
+			if checked[e.ID] || !finishedExecutionStatuses[e.Status] {
+				continue
+			}
+			checked[e.ID] = true
+
+			detailed, err := r.client.getExecution(ctx, string(e.ID), true)
+			if err != nil {
+				return nil, err
+			}
+			if len(detailed.Data) == 0 || string(detailed.Data) == "null" {
+				return nil, fmt.Errorf("execution %s of workflow %s was saved without its data, so it cannot be matched to the webhook call; "+
+					"make sure the workflow and the n8n instance save execution data", e.ID, workflowID)
+			}
+			if executionHasRunID(detailed, webhookNodes, runID) {
+				return detailed, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(executionPollInterval):
+		}
+	}
+}
+
+// workflowRunWebhookNodes returns the names of the Webhook nodes of the
+// workflow. It fails when the workflow cannot be run and followed: without a
+// Webhook node, or when its settings keep its executions from being saved.
+func workflowRunWebhookNodes(workflow *workflowDataSourceModel) ([]string, error) {
+	if workflow.Settings != nil {
+		if workflow.Settings.SaveDataSuccessExecution.ValueString() == "none" {
+			return nil, fmt.Errorf("workflow %s does not save successful executions (save_data_success_execution is \"none\"), so its execution cannot be followed", workflow.ID.ValueString())
+		}
+		if workflow.Settings.SaveDataErrorExecution.ValueString() == "none" {
+			return nil, fmt.Errorf("workflow %s does not save failed executions (save_data_error_execution is \"none\"), so its execution cannot be followed", workflow.ID.ValueString())
+		}
+	}
+
+	var names []string
+	for _, n := range workflow.Nodes {
+		if n.Type.ValueString() == webhookNodeType {
+			names = append(names, n.Name.ValueString())
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("workflow %s has no Webhook node", workflow.ID.ValueString())
+	}
+
+	return names, nil
+}
+
+// executionHasRunID reports whether one of the given Webhook nodes received
+// the run ID in the request headers of the execution. The execution must
+// include its data.
+func executionHasRunID(e *apiExecution, webhookNodes []string, runID string) bool {
+	var data struct {
+		ResultData struct {
+			RunData map[string][]struct {
+				Data struct {
+					Main [][]struct {
+						JSON struct {
+							Headers map[string]interface{} `json:"headers"`
+						} `json:"json"`
+					} `json:"main"`
+				} `json:"data"`
+			} `json:"runData"`
+		} `json:"resultData"`
+	}
+	if json.Unmarshal(e.Data, &data) != nil {
+		return false
+	}
+
+	// Header names are lowercased by the Webhook node
+	name := strings.ToLower(workflowRunHeader)
+	for _, nodeName := range webhookNodes {
+		for _, run := range data.ResultData.RunData[nodeName] {
+			for _, output := range run.Data.Main {
+				for _, item := range output {
+					if value, ok := item.JSON.Headers[name].(string); ok && value == runID {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// parseWorkflowRunTimeout returns the configured timeout or the default.
+func parseWorkflowRunTimeout(value types.String) (time.Duration, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultWorkflowRunTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("the timeout must be a positive duration such as 30s or 5m, got %q", value.ValueString())
+	}
+
+	return timeout, nil
+}
+
+// newWorkflowRunID returns a random ID for a run.
+func newWorkflowRunID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate run ID: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
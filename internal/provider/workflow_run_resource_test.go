@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWorkflowRunResourceRunWorkflow(t *testing.T) {
+	executionPollInterval = time.Millisecond
+	defer func() { executionPollInterval = 2 * time.Second }()
+
+	var triggered, lists atomic.Int32
+	fetched := map[string]int{}
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/workflows/wf-1":
+			_, _ = w.Write([]byte(`{"id":"wf-1","name":"Smoke test","nodes":[{"name":"Webhook","type":"n8n-nodes-base.webhook","parameters":{"path":"smoke-test"}},{"name":"Set","type":"n8n-nodes-base.set","parameters":{}}],"connections":{},"settings":{}}`))
+		case "/webhook/smoke-test":
+			if r.Header.Get("X-N8N-API-KEY") != "" {
+				t.Error("API key sent to webhook")
+			}
+			if got := r.Header.Get(workflowRunHeader); got != "run-1" {
+				t.Errorf("%s = %q, want run-1", workflowRunHeader, got)
+			}
+			triggered.Add(1)
+			_, _ = w.Write([]byte(`{"message":"Workflow was started"}`))
+		case "/api/v1/executions":
+			if r.URL.Query().Get("includeData") != "false" {
+				t.Error("executions listed with data")
+			}
+			if triggered.Load() == 0 {
+				// Executions from before the webhook call are never checked
+				_, _ = w.Write([]byte(`{"data":[{"id":41,"workflowId":"wf-1","status":"success"}],"nextCursor":null}`))
+				return
+			}
+			if lists.Add(1) < 3 {
+				_, _ = w.Write([]byte(`{"data":[{"id":43,"workflowId":"wf-1","status":"success"},{"id":42,"workflowId":"wf-1","status":"running"},{"id":41,"workflowId":"wf-1","status":"success"}],"nextCursor":null}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":43,"workflowId":"wf-1","status":"success"},{"id":42,"workflowId":"wf-1","status":"error"},{"id":41,"workflowId":"wf-1","status":"success"}],"nextCursor":null}`))
+		case "/api/v1/executions/42", "/api/v1/executions/43":
+			if r.URL.Query().Get("includeData") != "true" {
+				t.Error("execution fetched without data")
+			}
+			id := strings.TrimPrefix(r.URL.Path, "/api/v1/executions/")
+			mu.Lock()
+			fetched[id]++
+			mu.Unlock()
+
+			// Execution 43 was started by something else with another run ID
+			runID := "other"
+			status := "success"
+			if id == "42" {
+				runID = "run-1"
+				status = "error"
+			}
+			_, _ = w.Write([]byte(`{"id":` + id + `,"workflowId":"wf-1","status":"` + status + `","data":{"resultData":{"runData":{"Webhook":[{"data":{"main":[[{"json":{"headers":{"x-terraform-run-id":"` + runID + `"}}}]]}}]}}}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	r := &workflowRunResource{client: &client{BaseURL: server.URL, APIKey: "secret", HTTPClient: server.Client()}}
+
+	e, err := r.runWorkflow(context.Background(), "wf-1", "run-1", http.MethodPost, "smoke-test", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if e.ID != "42" || e.Status != "error" {
+		t.Errorf("execution = %+v", e)
+	}
+	if fetched["42"] != 1 || fetched["43"] != 1 {
+		t.Errorf("executions fetched with data %v, want each once", fetched)
+	}
+}
+
+func TestWorkflowRunWebhookNodes(t *testing.T) {
+	webhook := node{Name: types.StringValue("Webhook"), Type: types.StringValue(webhookNodeType)}
+
+	testCases := map[string]struct {
+		workflow workflowDataSourceModel
+		expected []string
+		errored  bool
+	}{
+		"webhook node": {
+			workflow: workflowDataSourceModel{
+				Nodes:    []node{webhook, {Name: types.StringValue("Set"), Type: types.StringValue("n8n-nodes-base.set")}},
+				Settings: &settings{SaveDataSuccessExecution: types.StringValue("all")},
+			},
+			expected: []string{"Webhook"},
+		},
+		"no webhook node": {
+			workflow: workflowDataSourceModel{
+				Nodes: []node{{Name: types.StringValue("Manual Trigger"), Type: types.StringValue("n8n-nodes-base.manualTrigger")}},
+			},
+			errored: true,
+		},
+		"successful executions not saved": {
+			workflow: workflowDataSourceModel{
+				Nodes:    []node{webhook},
+				Settings: &settings{SaveDataSuccessExecution: types.StringValue("none")},
+			},
+			errored: true,
+		},
+		"failed executions not saved": {
+			workflow: workflowDataSourceModel{
+				Nodes:    []node{webhook},
+				Settings: &settings{SaveDataErrorExecution: types.StringValue("none")},
+			},
+			errored: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := workflowRunWebhookNodes(&testCase.workflow)
+			if (err != nil) != testCase.errored {
+				t.Fatalf("error = %v, want error %t", err, testCase.errored)
+			}
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("webhook nodes = %v, want %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestExecutionHasRunID(t *testing.T) {
+	testCases := map[string]struct {
+		data     string
+		expected bool
+	}{
+		"webhook node headers": {
+			data:     `{"resultData":{"runData":{"Webhook":[{"data":{"main":[[{"json":{"headers":{"x-terraform-run-id":"run-1"}}}]]}}]}}}`,
+			expected: true,
+		},
+		"other run": {
+			data:     `{"resultData":{"runData":{"Webhook":[{"data":{"main":[[{"json":{"headers":{"x-terraform-run-id":"run-2"}}}]]}}]}}}`,
+			expected: false,
+		},
+		"run ID in another node": {
+			data:     `{"resultData":{"runData":{"Webhook":[{"data":{"main":[[{"json":{"headers":{}}}]]}}],"Set":[{"data":{"main":[[{"json":{"headers":{"x-terraform-run-id":"run-1"},"note":"run-1"}}]]}}]}}}`,
+			expected: false,
+		},
+		"no run data": {
+			data:     `{"resultData":{}}`,
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			e := &apiExecution{ID: "42", Data: []byte(testCase.data)}
+			if got := executionHasRunID(e, []string{"Webhook"}, "run-1"); got != testCase.expected {
+				t.Errorf("executionHasRunID() = %t, want %t", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestExecutionErrorMessage(t *testing.T) {
+	testCases := map[string]struct {
+		data     string
+		expected string
+	}{
+		"node error": {
+			data:     `{"resultData":{"error":{"message":"Request failed with status code 401","description":"Authorization failed","node":{"name":"HTTP Request"}},"lastNodeExecuted":"HTTP Request"}}`,
+			expected: `node "HTTP Request": Request failed with status code 401: Authorization failed`,
+		},
+		"workflow error": {
+			data:     `{"resultData":{"error":{"message":"Workflow did not finish"},"lastNodeExecuted":"Wait"}}`,
+			expected: `node "Wait": Workflow did not finish`,
+		},
+		"no data": {
+			expected: "execution 42 ended with status crashed",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			e := &apiExecution{ID: "42", Status: "crashed", Data: []byte(testCase.data)}
+			if got := executionErrorMessage(e); got != testCase.expected {
+				t.Errorf("executionErrorMessage() = %q, want %q", got, testCase.expected)
+			}
+		})
+	}
+}
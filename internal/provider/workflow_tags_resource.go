@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edenreich/n8n-cli/n8n"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &workflowTagsResource{}
+	_ resource.ResourceWithConfigure   = &workflowTagsResource{}
+	_ resource.ResourceWithImportState = &workflowTagsResource{}
+)
+
+// NewWorkflowTagsResource is a helper function to simplify the provider implementation.
+func NewWorkflowTagsResource() resource.Resource {
+	return &workflowTagsResource{}
+}
+
+// workflowTagsResource is the resource implementation.
+type workflowTagsResource struct {
+	client *client
+}
+
+// workflowTagsResourceModel maps the resource schema data.
+type workflowTagsResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	WorkflowID types.String `tfsdk:"workflow_id"`
+	TagIDs     types.Set    `tfsdk:"tag_ids"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *workflowTagsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *workflowTagsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_tags"
+}
+
+// Schema defines the schema for the resource.
+func (r *workflowTagsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the tags of a workflow without managing its content. " +
+			"Do not combine with the `tags` attribute of `n8n_workflow` for the same workflow.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Workflow ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_id": schema.StringAttribute{
+				Description: "The ID of the workflow to tag.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tag_ids": schema.SetAttribute{
+				Description: "The IDs of the tags of the workflow. Tags not listed here are removed.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *workflowTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan workflowTagsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setTags(ctx, &plan, &resp.State, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *workflowTagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Keep the prior state while the provider configuration is unknown
+	if r.client == nil {
+		return
+	}
+
+	var state workflowTagsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed workflow tags from n8n
+	workflowTags, err := r.client.getWorkflowTags(ctx, state.ID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n Workflow Tags",
+			err.Error(),
+		)
+		return
+	}
+
+	state.WorkflowID = state.ID
+	state.TagIDs = tagIDsSetValue(workflowTags)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *workflowTagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan workflowTagsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setTags(ctx, &plan, &resp.State, &resp.Diagnostics)
+}
+
+// Delete removes all tags from the workflow.
+func (r *workflowTagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state workflowTagsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.updateWorkflowTags(ctx, state.ID.ValueString(), []string{})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete n8n Workflow Tags",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the tags of an existing workflow by the workflow ID.
+func (r *workflowTagsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// setTags replaces the workflow tags with the planned ones and saves the result to state.
+func (r *workflowTagsResource) setTags(ctx context.Context, plan *workflowTagsResourceModel, state *tfsdk.State, diags *diag.Diagnostics) {
+	var tagIDs []string
+	diags.Append(plan.TagIDs.ElementsAs(ctx, &tagIDs, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	workflowTags, err := r.client.updateWorkflowTags(ctx, plan.WorkflowID.ValueString(), tagIDs)
+	if err != nil {
+		diags.AddError(
+			"Unable to Set n8n Workflow Tags",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = plan.WorkflowID
+	plan.TagIDs = tagIDsSetValue(workflowTags)
+
+	// Set state
+	diags.Append(state.Set(ctx, plan)...)
+}
+
+// tagIDsSetValue returns the IDs of the given tags as a Terraform set.
+func tagIDsSetValue(tags []n8n.Tag) types.Set {
+	elements := make([]attr.Value, len(tags))
+	for i, t := range tags {
+		elements[i] = types.StringPointerValue(t.Id)
+	}
+
+	return types.SetValueMust(types.StringType, elements)
+}
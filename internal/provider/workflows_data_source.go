@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/edenreich/n8n-cli/n8n"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &workflowsDataSource{}
+	_ datasource.DataSourceWithConfigure = &workflowsDataSource{}
+)
+
+// NewWorkflowsDataSource is a helper function to simplify the provider implementation.
+func NewWorkflowsDataSource() datasource.DataSource {
+	return &workflowsDataSource{}
+}
+
+// workflowsDataSource is the data source implementation.
+type workflowsDataSource struct {
+	client *client
+}
+
+// workflowsDataSourceModel maps the data source schema data.
+type workflowsDataSourceModel struct {
+	Active    types.Bool        `tfsdk:"active"`
+	Tags      []types.String    `tfsdk:"tags"`
+	Name      types.String      `tfsdk:"name"`
+	ProjectID types.String      `tfsdk:"project_id"`
+	Workflows []workflowSummary `tfsdk:"workflows"`
+}
+
+// workflowSummary represents a workflow in a list of workflows.
+type workflowSummary struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Active    types.Bool   `tfsdk:"active"`
+	Tags      []tag        `tfsdk:"tags"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *workflowsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *workflowsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflows"
+}
+
+// Schema defines the schema for the data source.
+func (d *workflowsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists workflows, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"active": schema.BoolAttribute{
+				Description: "Only list workflows with this active state.",
+				Optional:    true,
+			},
+			"tags": schema.ListAttribute{
+				Description: "Only list workflows that have all of these tag names.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Only list workflows with this name.",
+				Optional:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "Only list workflows of this project.",
+				Optional:    true,
+			},
+			"workflows": schema.ListNestedAttribute{
+				Description: "The matching workflows.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Workflow ID",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the workflow.",
+							Computed:    true,
+						},
+						"active": schema.BoolAttribute{
+							Description: "Whether the workflow is active.",
+							Computed:    true,
+						},
+						"tags": schema.ListNestedAttribute{
+							Description: "The tags of the workflow.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Description: "Tag ID",
+										Computed:    true,
+									},
+									"name": schema.StringAttribute{
+										Description: "Tag name",
+										Computed:    true,
+									},
+									"created_at": schema.StringAttribute{
+										Description: "Tag creation date",
+										Computed:    true,
+									},
+									"updated_at": schema.StringAttribute{
+										Description: "Tag last update date",
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"created_at": schema.StringAttribute{
+							Description: "The creation date of the workflow.",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "The last update date of the workflow.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *workflowsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredClientError(&resp.Diagnostics)
+		return
+	}
+
+	var state workflowsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := n8n.GetWorkflowsParams{
+		Active:    state.Active.ValueBoolPointer(),
+		Name:      state.Name.ValueStringPointer(),
+		ProjectId: state.ProjectID.ValueStringPointer(),
+	}
+	if len(state.Tags) > 0 {
+		tagNames := make([]string, len(state.Tags))
+		for i, t := range state.Tags {
+			tagNames[i] = t.ValueString()
+		}
+		joined := strings.Join(tagNames, ",")
+		params.Tags = &joined
+	}
+
+	workflows, err := d.client.listWorkflows(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read n8n Workflows",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Workflows = make([]workflowSummary, len(workflows))
+	for i, workflow := range workflows {
+		tags := []tag{}
+		if workflow.Tags != nil {
+			tags = flattenTags(*workflow.Tags)
+		}
+		state.Workflows[i] = workflowSummary{
+			ID:        types.StringPointerValue(workflow.Id),
+			Name:      types.StringValue(workflow.Name),
+			Active:    types.BoolPointerValue(workflow.Active),
+			Tags:      tags,
+			CreatedAt: convertTimeToTypesString(workflow.CreatedAt),
+			UpdatedAt: convertTimeToTypesString(workflow.UpdatedAt),
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}